@@ -0,0 +1,220 @@
+// Copyright 2026 Miguel Angel Rivera Notararigo. All rights reserved.
+// This source code was released under the MIT license.
+
+package client
+
+import (
+	"net/http"
+	"sync"
+	"time"
+)
+
+// Default circuit breaker settings.
+const (
+	DefaultFailureThreshold = 5
+	DefaultOpenTimeout      = 30 * time.Second
+)
+
+// Circuit breaker states.
+const (
+	BreakerClosed BreakerState = iota
+	BreakerOpen
+	BreakerHalfOpen
+)
+
+// BreakerState is the state of a circuit breaker.
+type BreakerState int
+
+func (s BreakerState) String() string {
+	switch s {
+	case BreakerClosed:
+		return "closed"
+	case BreakerOpen:
+		return "open"
+	case BreakerHalfOpen:
+		return "half-open"
+	}
+
+	return "unknown"
+}
+
+// Breaker is a circuit breaker. After a number of consecutive failed round
+// trips, the circuit opens and round trips fail fast with ErrCircuitOpen.
+// Once the open timeout passes, the circuit is half-open and a single round
+// trip is allowed, if it succeeds the circuit closes, otherwise it opens
+// again.
+//
+// Breakers track a single upstream, so different breakers should be used for
+// different services.
+//
+//	b := client.NewBreaker(client.WithFailureThreshold(3))
+//	rt := client.Adapt(nil, client.Retry(), b.Adapt)
+type Breaker struct {
+	o breakerOptions
+
+	mu       sync.Mutex
+	state    BreakerState
+	failures int
+	openedAt time.Time
+	probing  bool
+}
+
+// NewBreaker creates a circuit breaker.
+func NewBreaker(opts ...BreakerOption) *Breaker {
+	o := breakerOptions{
+		threshold: DefaultFailureThreshold,
+		timeout:   DefaultOpenTimeout,
+		failureIf: failed,
+	}
+
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	return &Breaker{o: o}
+}
+
+// CircuitBreaker creates an adapter with its own Breaker.
+func CircuitBreaker(opts ...BreakerOption) Adapter {
+	return NewBreaker(opts...).Adapt
+}
+
+// Adapt wraps rt with b, it may be used as Adapter.
+func (b *Breaker) Adapt(rt http.RoundTripper) http.RoundTripper {
+	nrt := func(r *http.Request) (*http.Response, error) {
+		if !b.allow() {
+			if r.Body != nil {
+				r.Body.Close()
+			}
+
+			return nil, ErrCircuitOpen
+		}
+
+		res, err := rt.RoundTrip(r)
+
+		// Canceled requests say nothing about the upstream.
+		if r.Context().Err() != nil {
+			b.release()
+		} else {
+			b.done(b.o.failureIf(res, err))
+		}
+
+		return res, err //nolint:wrapcheck
+	}
+
+	return RoundTripperFunc(nrt)
+}
+
+// State returns the current state of b.
+func (b *Breaker) State() BreakerState {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.state == BreakerOpen && time.Since(b.openedAt) >= b.o.timeout {
+		return BreakerHalfOpen
+	}
+
+	return b.state
+}
+
+func (b *Breaker) allow() bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	switch b.state {
+	case BreakerClosed:
+		return true
+	case BreakerOpen:
+		if time.Since(b.openedAt) < b.o.timeout {
+			return false
+		}
+
+		b.state = BreakerHalfOpen
+	}
+
+	if b.probing {
+		return false
+	}
+
+	b.probing = true
+
+	return true
+}
+
+func (b *Breaker) done(failed bool) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	switch b.state {
+	case BreakerClosed:
+		if !failed {
+			b.failures = 0
+			return
+		}
+
+		if b.failures++; b.failures >= b.o.threshold {
+			b.open()
+		}
+	case BreakerHalfOpen:
+		b.probing = false
+
+		if failed {
+			b.open()
+		} else {
+			b.state = BreakerClosed
+			b.failures = 0
+		}
+	case BreakerOpen:
+	}
+}
+
+func (b *Breaker) open() {
+	b.state = BreakerOpen
+	b.openedAt = time.Now()
+}
+
+func (b *Breaker) release() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.state == BreakerHalfOpen {
+		b.probing = false
+	}
+}
+
+// BreakerOption configures circuit breakers.
+type BreakerOption func(*breakerOptions)
+
+// WithFailureIf sets the function that reports if a round trip failed.
+// Default considers errors and responses with status 5xx as failures.
+func WithFailureIf(fn func(res *http.Response, err error) bool) BreakerOption {
+	return func(o *breakerOptions) {
+		o.failureIf = fn
+	}
+}
+
+// WithFailureThreshold sets the number of consecutive failures that open the
+// circuit. Default is DefaultFailureThreshold.
+func WithFailureThreshold(n int) BreakerOption {
+	return func(o *breakerOptions) {
+		o.threshold = n
+	}
+}
+
+// WithOpenTimeout sets how long the circuit stays open before allowing a
+// round trip. Default is DefaultOpenTimeout.
+func WithOpenTimeout(d time.Duration) BreakerOption {
+	return func(o *breakerOptions) {
+		o.timeout = d
+	}
+}
+
+type breakerOptions struct {
+	threshold int
+	timeout   time.Duration
+	failureIf func(*http.Response, error) bool
+}
+
+func failed(res *http.Response, err error) bool {
+	return err != nil || res.StatusCode >= http.StatusInternalServerError
+}
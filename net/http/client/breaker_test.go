@@ -0,0 +1,107 @@
+// Copyright 2026 Miguel Angel Rivera Notararigo. All rights reserved.
+// This source code was released under the MIT license.
+
+package client_test
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"go.ntrrg.dev/ntgo/net/http/client"
+)
+
+func TestBreaker(t *testing.T) {
+	t.Parallel()
+
+	var failing, calls int32 = 1, 0
+
+	s := httptest.NewServer(http.HandlerFunc(
+		func(w http.ResponseWriter, r *http.Request) {
+			atomic.AddInt32(&calls, 1)
+
+			if atomic.LoadInt32(&failing) == 1 {
+				w.WriteHeader(http.StatusInternalServerError)
+			}
+		},
+	))
+
+	defer s.Close()
+
+	b := client.NewBreaker(
+		client.WithFailureThreshold(2),
+		client.WithOpenTimeout(50*time.Millisecond),
+	)
+
+	c := &http.Client{Transport: client.Adapt(s.Client().Transport, b.Adapt)}
+
+	get := func() error {
+		t.Helper()
+
+		res, err := c.Get(s.URL) //nolint:noctx
+		if err != nil {
+			return err //nolint:wrapcheck
+		}
+
+		res.Body.Close()
+
+		return nil
+	}
+
+	check := func(want client.BreakerState) {
+		t.Helper()
+
+		if got := b.State(); got != want {
+			t.Fatalf("invalid state. got: %v, want: %v", got, want)
+		}
+	}
+
+	for i := 0; i < 2; i++ {
+		check(client.BreakerClosed)
+
+		if err := get(); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	check(client.BreakerOpen)
+
+	if err := get(); !errors.Is(err, client.ErrCircuitOpen) {
+		t.Fatalf("invalid error. got: %v, want: %v", err, client.ErrCircuitOpen)
+	}
+
+	if n := atomic.LoadInt32(&calls); n != 2 {
+		t.Errorf("invalid number of calls. got: %d, want: %d", n, 2)
+	}
+
+	// Failed probe.
+	time.Sleep(60 * time.Millisecond)
+	check(client.BreakerHalfOpen)
+
+	if err := get(); err != nil {
+		t.Fatal(err)
+	}
+
+	check(client.BreakerOpen)
+
+	// Successful probe.
+	atomic.StoreInt32(&failing, 0)
+	time.Sleep(60 * time.Millisecond)
+
+	if err := get(); err != nil {
+		t.Fatal(err)
+	}
+
+	check(client.BreakerClosed)
+
+	if err := get(); err != nil {
+		t.Fatal(err)
+	}
+
+	if n := atomic.LoadInt32(&calls); n != 5 {
+		t.Errorf("invalid number of calls. got: %d, want: %d", n, 5)
+	}
+}
@@ -0,0 +1,45 @@
+// Copyright 2026 Miguel Angel Rivera Notararigo. All rights reserved.
+// This source code was released under the MIT license.
+
+package client
+
+import (
+	"io"
+	"net/http"
+)
+
+// Adapter is a wrapper for http.RoundTripper. Takes an http.RoundTripper as
+// argument and creates a new one that may run code before and/or after
+// calling the given round tripper.
+type Adapter func(http.RoundTripper) http.RoundTripper
+
+// Adapt wraps an http.RoundTripper into a list of Adapters. Adapters will be
+// wrapped right to left (they will be executed left to right). If rt is nil,
+// http.DefaultTransport is used.
+func Adapt(rt http.RoundTripper, a ...Adapter) http.RoundTripper {
+	if rt == nil {
+		rt = http.DefaultTransport
+	}
+
+	for i := len(a) - 1; i >= 0; i-- {
+		rt = a[i](rt)
+	}
+
+	return rt
+}
+
+// RoundTripperFunc is an adapter to allow the use of ordinary functions as
+// http.RoundTripper.
+type RoundTripperFunc func(r *http.Request) (*http.Response, error)
+
+// RoundTrip implements http.RoundTripper.
+func (f RoundTripperFunc) RoundTrip(r *http.Request) (*http.Response, error) {
+	return f(r)
+}
+
+// discard reads a bounded amount of data from body before closing it, so the
+// connection may be reused.
+func discard(body io.ReadCloser) {
+	io.CopyN(io.Discard, body, 4<<10) //nolint:errcheck
+	body.Close()
+}
@@ -0,0 +1,92 @@
+// Copyright 2026 Miguel Angel Rivera Notararigo. All rights reserved.
+// This source code was released under the MIT license.
+
+package client_test
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"go.ntrrg.dev/ntgo/net/http/client"
+)
+
+func ExampleAdapt() {
+	s := httptest.NewServer(http.HandlerFunc(
+		func(w http.ResponseWriter, r *http.Request) {
+			fmt.Fprint(w, r.Header.Get("User-Agent"))
+		},
+	))
+
+	defer s.Close()
+
+	c := &http.Client{
+		Transport: client.Adapt(
+			nil,
+			client.Retry(),
+			client.Timeout(5*time.Second),
+			client.SetHeader("User-Agent", "ntgo"),
+		),
+	}
+
+	res, err := c.Get(s.URL) //nolint:noctx
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+
+	defer res.Body.Close()
+
+	body, _ := io.ReadAll(res.Body)
+
+	fmt.Printf("Status: %v\n", res.Status)
+	fmt.Printf("Body: %s", body)
+	// Output:
+	// Status: 200 OK
+	// Body: ntgo
+}
+
+func TestAdapt(t *testing.T) {
+	t.Parallel()
+
+	var got string
+
+	mark := func(s string) client.Adapter {
+		return func(rt http.RoundTripper) http.RoundTripper {
+			nrt := func(r *http.Request) (*http.Response, error) {
+				got += s
+				res, err := rt.RoundTrip(r)
+				got += s
+
+				return res, err //nolint:wrapcheck
+			}
+
+			return client.RoundTripperFunc(nrt)
+		}
+	}
+
+	rt := client.Adapt(
+		client.RoundTripperFunc(func(r *http.Request) (*http.Response, error) {
+			got += "-"
+			return &http.Response{StatusCode: 200, Body: http.NoBody}, nil
+		}),
+
+		mark("a"), mark("b"), mark("c"),
+	)
+
+	r := httptest.NewRequest(http.MethodGet, "/", nil)
+
+	res, err := rt.RoundTrip(r)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	res.Body.Close()
+
+	if want := "abc-cba"; got != want {
+		t.Errorf("invalid execution order. got: %q, want: %q", got, want)
+	}
+}
@@ -0,0 +1,118 @@
+// Copyright 2026 Miguel Angel Rivera Notararigo. All rights reserved.
+// This source code was released under the MIT license.
+
+package client
+
+import (
+	"bytes"
+	"compress/gzip"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+)
+
+// Gzip requests compressed responses and decompresses them. Unlike the
+// transparent decompression from http.Transport, it works when the
+// Accept-Encoding header is set by other adapters or round trippers.
+func Gzip() Adapter {
+	return func(rt http.RoundTripper) http.RoundTripper {
+		nrt := func(r *http.Request) (*http.Response, error) {
+			if r.Header.Get("Accept-Encoding") == "" {
+				r = r.Clone(r.Context())
+				r.Header.Set("Accept-Encoding", "gzip")
+			}
+
+			res, err := rt.RoundTrip(r)
+			if err != nil {
+				return nil, err //nolint:wrapcheck
+			}
+
+			if !strings.EqualFold(res.Header.Get("Content-Encoding"), "gzip") {
+				return res, nil
+			}
+
+			res.Body = &gzipBody{body: res.Body}
+			res.Header.Del("Content-Encoding")
+			res.Header.Del("Content-Length")
+			res.ContentLength = -1
+			res.Uncompressed = true
+
+			return res, nil
+		}
+
+		return RoundTripperFunc(nrt)
+	}
+}
+
+// GzipRequest compresses request bodies. The compression level is given as an
+// integer value according to the compress/flate package. Requests with a
+// Content-Encoding header are not modified.
+func GzipRequest(level int) Adapter {
+	if _, err := gzip.NewWriterLevel(io.Discard, level); err != nil {
+		panic(fmt.Errorf("bad compression level: %w", err))
+	}
+
+	return func(rt http.RoundTripper) http.RoundTripper {
+		nrt := func(r *http.Request) (*http.Response, error) {
+			if r.Body == nil || r.Body == http.NoBody ||
+				r.Header.Get("Content-Encoding") != "" {
+				return rt.RoundTrip(r) //nolint:wrapcheck
+			}
+
+			var buf bytes.Buffer
+
+			gz, _ := gzip.NewWriterLevel(&buf, level)
+
+			_, err := io.Copy(gz, r.Body)
+			r.Body.Close()
+
+			if err == nil {
+				err = gz.Close()
+			}
+
+			if err != nil {
+				return nil, err //nolint:wrapcheck
+			}
+
+			data := buf.Bytes()
+
+			r2 := r.Clone(r.Context())
+			r2.Header.Set("Content-Encoding", "gzip")
+			r2.ContentLength = int64(len(data))
+			r2.Body = io.NopCloser(bytes.NewReader(data))
+
+			r2.GetBody = func() (io.ReadCloser, error) {
+				return io.NopCloser(bytes.NewReader(data)), nil
+			}
+
+			return rt.RoundTrip(r2) //nolint:wrapcheck
+		}
+
+		return RoundTripperFunc(nrt)
+	}
+}
+
+// gzipBody decompresses body on first read, so empty bodies don't fail
+// until they are read.
+type gzipBody struct {
+	body io.ReadCloser
+	gz   *gzip.Reader
+	err  error
+}
+
+func (b *gzipBody) Read(p []byte) (int, error) {
+	if b.gz == nil && b.err == nil {
+		b.gz, b.err = gzip.NewReader(b.body)
+	}
+
+	if b.err != nil {
+		return 0, b.err
+	}
+
+	return b.gz.Read(p) //nolint:wrapcheck
+}
+
+func (b *gzipBody) Close() error {
+	return b.body.Close() //nolint:wrapcheck
+}
@@ -0,0 +1,96 @@
+// Copyright 2026 Miguel Angel Rivera Notararigo. All rights reserved.
+// This source code was released under the MIT license.
+
+package client_test
+
+import (
+	"compress/gzip"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"go.ntrrg.dev/ntgo/net/http/client"
+)
+
+func TestGzip(t *testing.T) {
+	t.Parallel()
+
+	data := strings.Repeat("hello, world\n", 100)
+
+	s := httptest.NewServer(http.HandlerFunc(
+		func(w http.ResponseWriter, r *http.Request) {
+			if r.Header.Get("Content-Encoding") == "gzip" {
+				gr, err := gzip.NewReader(r.Body)
+				if err != nil {
+					http.Error(w, err.Error(), http.StatusBadRequest)
+					return
+				}
+
+				r.Body = gr
+			}
+
+			body, err := io.ReadAll(r.Body)
+			if err != nil {
+				http.Error(w, err.Error(), http.StatusBadRequest)
+				return
+			}
+
+			if !strings.Contains(r.Header.Get("Accept-Encoding"), "gzip") {
+				w.Write(body) //nolint:errcheck
+				return
+			}
+
+			w.Header().Set("Content-Encoding", "gzip")
+
+			gw := gzip.NewWriter(w)
+			gw.Write(body) //nolint:errcheck
+			gw.Close()
+		},
+	))
+
+	defer s.Close()
+
+	c := &http.Client{
+		Transport: client.Adapt(
+			s.Client().Transport,
+			client.Gzip(),
+			client.GzipRequest(gzip.BestCompression),
+		),
+	}
+
+	body := strings.NewReader(data)
+
+	res, err := c.Post(s.URL, "text/plain", body) //nolint:noctx
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	defer res.Body.Close()
+
+	got, err := io.ReadAll(res.Body)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if string(got) != data {
+		t.Errorf("invalid body. got: %q, want: %q", got, data)
+	}
+
+	if !res.Uncompressed || res.Header.Get("Content-Encoding") != "" {
+		t.Errorf("response was not decompressed: %v", res.Header)
+	}
+}
+
+func TestGzipRequest_badLevel(t *testing.T) {
+	t.Parallel()
+
+	defer func() {
+		if recover() == nil {
+			t.Error("invalid compression level was accepted")
+		}
+	}()
+
+	client.GzipRequest(42)
+}
@@ -0,0 +1,29 @@
+// Copyright 2026 Miguel Angel Rivera Notararigo. All rights reserved.
+// This source code was released under the MIT license.
+
+/*
+Package client provides HTTP client utilities. As the middleware package does
+for handlers, the Adapter pattern is used for wrapping http.RoundTripper
+implementations. An adapter may run code before and/or after the round trip
+it is wrapping.
+
+	c := &http.Client{
+		Transport: client.Adapt(
+			http.DefaultTransport,
+			client.Log(logger),
+			client.Retry(),
+			client.Timeout(5*time.Second),
+			client.Gzip(),
+		),
+	}
+
+Adapters are executed left to right, which means the order matters. In the
+example above, every request is logged once, while each attempt made by Retry
+has its own timeout.
+
+Round trippers must not modify requests, so adapters that need to do it work
+with copies.
+*/
+package client
+
+// API Status: testing
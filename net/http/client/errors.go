@@ -0,0 +1,13 @@
+// Copyright 2026 Miguel Angel Rivera Notararigo. All rights reserved.
+// This source code was released under the MIT license.
+
+package client
+
+import (
+	nthttp "go.ntrrg.dev/ntgo/net/http"
+)
+
+// Err is the main error group for this package.
+var Err = nthttp.Err.New("client", "client package errors")
+
+var ErrCircuitOpen = Err.New("circuit-open", "circuit breaker is open")
@@ -0,0 +1,38 @@
+// Copyright 2026 Miguel Angel Rivera Notararigo. All rights reserved.
+// This source code was released under the MIT license.
+
+package client
+
+import (
+	"net/http"
+)
+
+// AddHeader creates/appends a HTTP header before calling the
+// http.RoundTripper.
+func AddHeader(key, value string) Adapter {
+	return headerAdapter(func(h http.Header) { h.Add(key, value) })
+}
+
+// DelHeader removes a HTTP header before calling the http.RoundTripper.
+func DelHeader(key string) Adapter {
+	return headerAdapter(func(h http.Header) { h.Del(key) })
+}
+
+// SetHeader creates/replaces a HTTP header before calling the
+// http.RoundTripper.
+func SetHeader(key, value string) Adapter {
+	return headerAdapter(func(h http.Header) { h.Set(key, value) })
+}
+
+func headerAdapter(fn func(http.Header)) Adapter {
+	return func(rt http.RoundTripper) http.RoundTripper {
+		nrt := func(r *http.Request) (*http.Response, error) {
+			r2 := r.Clone(r.Context())
+			fn(r2.Header)
+
+			return rt.RoundTrip(r2) //nolint:wrapcheck
+		}
+
+		return RoundTripperFunc(nrt)
+	}
+}
@@ -0,0 +1,67 @@
+// Copyright 2026 Miguel Angel Rivera Notararigo. All rights reserved.
+// This source code was released under the MIT license.
+
+package client_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	"go.ntrrg.dev/ntgo/net/http/client"
+)
+
+func TestHeaders(t *testing.T) {
+	t.Parallel()
+
+	var got http.Header
+
+	s := httptest.NewServer(http.HandlerFunc(
+		func(w http.ResponseWriter, r *http.Request) {
+			got = r.Header
+		},
+	))
+
+	defer s.Close()
+
+	c := &http.Client{
+		Transport: client.Adapt(
+			s.Client().Transport,
+			client.SetHeader("Authorization", "Bearer token"),
+			client.AddHeader("X-Tag", "a"),
+			client.AddHeader("X-Tag", "b"),
+			client.DelHeader("X-Debug"),
+		),
+	}
+
+	r, err := http.NewRequest(http.MethodGet, s.URL, nil) //nolint:noctx
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	r.Header.Set("X-Debug", "1")
+
+	res, err := c.Do(r)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	res.Body.Close()
+
+	if v := got.Get("Authorization"); v != "Bearer token" {
+		t.Errorf("invalid Authorization. got: %q, want: %q", v, "Bearer token")
+	}
+
+	if v := got.Values("X-Tag"); !reflect.DeepEqual(v, []string{"a", "b"}) {
+		t.Errorf("invalid X-Tag. got: %q, want: %q", v, []string{"a", "b"})
+	}
+
+	if v := got.Get("X-Debug"); v != "" {
+		t.Errorf("invalid X-Debug. got: %q, want: %q", v, "")
+	}
+
+	if r.Header.Get("Authorization") != "" || r.Header.Get("X-Debug") == "" {
+		t.Errorf("original request was modified: %v", r.Header)
+	}
+}
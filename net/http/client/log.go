@@ -0,0 +1,49 @@
+// Copyright 2026 Miguel Angel Rivera Notararigo. All rights reserved.
+// This source code was released under the MIT license.
+
+package client
+
+import (
+	"log"
+	"net/http"
+	"time"
+)
+
+// Log logs round trips with l, or the standard logger if l is nil. Entries
+// have the request method and URL (without password), the response status or
+// the error, and the duration.
+//
+//	GET https://example.com/ -> 200 OK (12.3ms)
+func Log(l *log.Logger) Adapter {
+	logf := log.Printf
+	if l != nil {
+		logf = l.Printf
+	}
+
+	return func(rt http.RoundTripper) http.RoundTripper {
+		nrt := func(r *http.Request) (*http.Response, error) {
+			start := time.Now()
+
+			res, err := rt.RoundTrip(r)
+
+			d := time.Since(start)
+			u := r.URL.Redacted()
+
+			m := r.Method
+			if m == "" {
+				m = http.MethodGet
+			}
+
+			if err != nil {
+				logf("%s %s -> error: %v (%v)", m, u, err, d)
+				return nil, err //nolint:wrapcheck
+			}
+
+			logf("%s %s -> %s (%v)", m, u, res.Status, d)
+
+			return res, nil
+		}
+
+		return RoundTripperFunc(nrt)
+	}
+}
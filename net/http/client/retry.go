@@ -0,0 +1,252 @@
+// Copyright 2026 Miguel Angel Rivera Notararigo. All rights reserved.
+// This source code was released under the MIT license.
+
+package client
+
+import (
+	"math/rand"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// Default retry settings.
+const (
+	DefaultRetries       = 3
+	DefaultMaxRetryAfter = time.Minute
+)
+
+// Retry retries failed round trips, which by default are network errors and
+// responses with status 429, 502, 503 and 504 (see WithRetryIf).
+//
+// Only requests that can be safely repeated are retried, their method must be
+// idempotent (or they must have an Idempotency-Key header) and their body must
+// be obtainable again with GetBody (http.NewRequest sets it for bytes and
+// strings readers). Requests with other methods must always have GetBody, even
+// if they don't have a body. Round trips are never retried after the request
+// context is done.
+//
+// The waiting time between attempts is given by the backoff function, unless
+// the response has a longer Retry-After header. If Retry-After exceeds the
+// maximum (see WithMaxRetryAfter), or the request context is done while
+// waiting, the last response is returned.
+func Retry(opts ...RetryOption) Adapter {
+	o := retryOptions{
+		retries:       DefaultRetries,
+		backoff:       ExponentialBackoff(100*time.Millisecond, 10*time.Second),
+		maxRetryAfter: DefaultMaxRetryAfter,
+		retryIf:       retryable,
+	}
+
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	return func(rt http.RoundTripper) http.RoundTripper {
+		nrt := func(r *http.Request) (*http.Response, error) {
+			if !replayable(r) {
+				return rt.RoundTrip(r) //nolint:wrapcheck
+			}
+
+			return o.do(rt, r)
+		}
+
+		return RoundTripperFunc(nrt)
+	}
+}
+
+// Backoff returns the waiting time before the retry number n, starting at 0.
+type Backoff func(n int) time.Duration
+
+// ExponentialBackoff doubles the waiting time with every retry, starting at
+// base and up to max. A random jitter of up to half the waiting time is
+// subtracted to spread retries from multiple clients.
+func ExponentialBackoff(base, max time.Duration) Backoff {
+	return func(n int) time.Duration {
+		d := base
+		for i := 0; i < n && d < max; i++ {
+			d *= 2
+		}
+
+		if d > max {
+			d = max
+		}
+
+		return d - time.Duration(rand.Int63n(int64(d/2)+1)) //nolint:gosec
+	}
+}
+
+// RetryOption configures Retry.
+type RetryOption func(*retryOptions)
+
+// WithBackoff sets the waiting time between attempts. Default is an
+// ExponentialBackoff from 100ms up to 10s.
+func WithBackoff(b Backoff) RetryOption {
+	return func(o *retryOptions) {
+		o.backoff = b
+	}
+}
+
+// WithMaxRetryAfter sets the maximum Retry-After accepted for retrying.
+// Default is DefaultMaxRetryAfter.
+func WithMaxRetryAfter(d time.Duration) RetryOption {
+	return func(o *retryOptions) {
+		o.maxRetryAfter = d
+	}
+}
+
+// WithRetries sets the maximum number of retries. Default is
+// DefaultRetries.
+func WithRetries(n int) RetryOption {
+	return func(o *retryOptions) {
+		o.retries = n
+	}
+}
+
+// WithRetryIf sets the function that reports if a round trip must be
+// retried. Errors from done request contexts are never retried.
+func WithRetryIf(fn func(res *http.Response, err error) bool) RetryOption {
+	return func(o *retryOptions) {
+		o.retryIf = fn
+	}
+}
+
+type retryOptions struct {
+	retries       int
+	backoff       Backoff
+	maxRetryAfter time.Duration
+	retryIf       func(*http.Response, error) bool
+}
+
+func (o retryOptions) do(
+	rt http.RoundTripper,
+	r *http.Request,
+) (*http.Response, error) {
+	ctx := r.Context()
+
+	for n := 0; ; n++ {
+		ar := r
+
+		if n > 0 {
+			ar = r.Clone(ctx)
+
+			if r.GetBody != nil {
+				body, err := r.GetBody()
+				if err != nil {
+					return nil, err //nolint:wrapcheck
+				}
+
+				ar.Body = body
+			}
+		}
+
+		res, err := rt.RoundTrip(ar)
+		if n >= o.retries || !o.retry(r, res, err) {
+			return res, err //nolint:wrapcheck
+		}
+
+		wait := o.backoff(n)
+
+		if res != nil {
+			if d, ok := retryAfter(res.Header.Get("Retry-After")); ok {
+				if d > o.maxRetryAfter {
+					return res, nil
+				}
+
+				if d > wait {
+					wait = d
+				}
+			}
+		}
+
+		t := time.NewTimer(wait)
+
+		select {
+		case <-ctx.Done():
+			t.Stop()
+			return res, err //nolint:wrapcheck
+		case <-t.C:
+		}
+
+		if res != nil {
+			discard(res.Body)
+		}
+	}
+}
+
+// retry reports if the round trip of r must be retried.
+func (o retryOptions) retry(
+	r *http.Request,
+	res *http.Response,
+	err error,
+) bool {
+	// Errors from done request contexts are never retried.
+	if r.Context().Err() != nil {
+		return false
+	}
+
+	return o.retryIf(res, err)
+}
+
+// replayable reports if r can be sent again.
+func replayable(r *http.Request) bool {
+	if r.Body != nil && r.Body != http.NoBody && r.GetBody == nil {
+		return false
+	}
+
+	switch r.Method {
+	case "", http.MethodGet, http.MethodHead, http.MethodOptions,
+		http.MethodTrace, http.MethodPut, http.MethodDelete:
+		return true
+	}
+
+	// Non-idempotent requests are only retried if their body is known.
+	if r.GetBody == nil {
+		return false
+	}
+
+	return r.Header.Get("Idempotency-Key") != "" ||
+		r.Header.Get("X-Idempotency-Key") != ""
+}
+
+func retryable(res *http.Response, err error) bool {
+	if err != nil {
+		return true
+	}
+
+	switch res.StatusCode {
+	case http.StatusTooManyRequests, http.StatusBadGateway,
+		http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return true
+	}
+
+	return false
+}
+
+// retryAfter parses Retry-After header values, which may be a number of
+// seconds or a date.
+func retryAfter(v string) (time.Duration, bool) {
+	if v == "" {
+		return 0, false
+	}
+
+	if s, err := strconv.Atoi(v); err == nil {
+		if s < 0 {
+			return 0, false
+		}
+
+		return time.Duration(s) * time.Second, true
+	}
+
+	t, err := http.ParseTime(v)
+	if err != nil {
+		return 0, false
+	}
+
+	d := time.Until(t)
+	if d < 0 {
+		d = 0
+	}
+
+	return d, true
+}
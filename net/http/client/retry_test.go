@@ -0,0 +1,264 @@
+// Copyright 2026 Miguel Angel Rivera Notararigo. All rights reserved.
+// This source code was released under the MIT license.
+
+package client_test
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"go.ntrrg.dev/ntgo/net/http/client"
+)
+
+func TestRetry(t *testing.T) {
+	t.Parallel()
+
+	noWait := client.WithBackoff(func(int) time.Duration { return 0 })
+
+	cases := []struct {
+		label      string
+		method     string
+		body       string
+		stream     bool
+		header     http.Header
+		failures   int32
+		retryAfter string
+		opts       []client.RetryOption
+		status     int
+		attempts   int32
+	}{
+		{
+			label: "success", method: "GET",
+			status: 200, attempts: 1,
+		},
+		{
+			label: "recovered", method: "GET", failures: 2,
+			status: 200, attempts: 3,
+		},
+		{
+			label: "exhausted", method: "GET", failures: 10,
+			opts:   []client.RetryOption{client.WithRetries(2)},
+			status: 503, attempts: 3,
+		},
+		{
+			label: "body", method: "PUT", body: "data", failures: 1,
+			status: 200, attempts: 2,
+		},
+		{
+			label: "non-idempotent", method: "POST", body: "data", failures: 1,
+			status: 503, attempts: 1,
+		},
+		{
+			label: "idempotency key", method: "POST", body: "data", failures: 1,
+			header: http.Header{"Idempotency-Key": {"1"}},
+			status: 200, attempts: 2,
+		},
+		{
+			label: "idempotency key stream", method: "POST", body: "data",
+			stream: true, failures: 1,
+			header: http.Header{"Idempotency-Key": {"1"}},
+			status: 503, attempts: 1,
+		},
+		{
+			label: "idempotency key without body", method: "POST", failures: 1,
+			header: http.Header{"Idempotency-Key": {"1"}},
+			status: 503, attempts: 1,
+		},
+		{
+			label: "retry after", method: "GET", failures: 1,
+			retryAfter: "0",
+			status:     200, attempts: 2,
+		},
+		{
+			label: "retry after exceeded", method: "GET", failures: 1,
+			retryAfter: "120",
+			status:     503, attempts: 1,
+		},
+		{
+			label: "retry if", method: "GET", failures: 1,
+			opts: []client.RetryOption{
+				client.WithRetryIf(func(*http.Response, error) bool { return false }),
+			},
+			status: 503, attempts: 1,
+		},
+	}
+
+	for _, c := range cases {
+		c := c
+
+		t.Run(c.label, func(t *testing.T) {
+			t.Parallel()
+
+			var attempts int32
+
+			s := httptest.NewServer(http.HandlerFunc(
+				func(w http.ResponseWriter, r *http.Request) {
+					n := atomic.AddInt32(&attempts, 1)
+
+					body, _ := io.ReadAll(r.Body)
+					if string(body) != c.body {
+						t.Errorf("invalid body. got: %q, want: %q", body, c.body)
+					}
+
+					if n <= c.failures {
+						if c.retryAfter != "" {
+							w.Header().Set("Retry-After", c.retryAfter)
+						}
+
+						w.WriteHeader(http.StatusServiceUnavailable)
+					}
+				},
+			))
+
+			defer s.Close()
+
+			opts := append([]client.RetryOption{noWait}, c.opts...)
+			hc := &http.Client{
+				Transport: client.Adapt(s.Client().Transport, client.Retry(opts...)),
+			}
+
+			var body io.Reader
+			if c.body != "" {
+				body = strings.NewReader(c.body)
+			}
+
+			// Unknown readers don't set GetBody.
+			if c.stream {
+				body = io.MultiReader(body)
+			}
+
+			r, err := http.NewRequest(c.method, s.URL, body) //nolint:noctx
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			for k, v := range c.header {
+				r.Header[k] = v
+			}
+
+			res, err := hc.Do(r)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			res.Body.Close()
+
+			if res.StatusCode != c.status {
+				t.Errorf("invalid status. got: %d, want: %d", res.StatusCode, c.status)
+			}
+
+			if n := atomic.LoadInt32(&attempts); n != c.attempts {
+				t.Errorf("invalid attempts. got: %d, want: %d", n, c.attempts)
+			}
+		})
+	}
+}
+
+func TestRetry_context(t *testing.T) {
+	t.Parallel()
+
+	s := httptest.NewServer(http.HandlerFunc(
+		func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusServiceUnavailable)
+		},
+	))
+
+	defer s.Close()
+
+	c := &http.Client{
+		Transport: client.Adapt(
+			s.Client().Transport,
+			client.Retry(client.WithBackoff(func(int) time.Duration {
+				return time.Hour
+			})),
+		),
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	r, err := http.NewRequestWithContext(ctx, http.MethodGet, s.URL, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	res, err := c.Do(r)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	res.Body.Close()
+
+	if res.StatusCode != http.StatusServiceUnavailable {
+		t.Errorf("invalid status. got: %d, want: %d", res.StatusCode, 503)
+	}
+}
+
+func TestRetry_canceled(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	var attempts int32
+
+	fail := func(r *http.Request) (*http.Response, error) {
+		atomic.AddInt32(&attempts, 1)
+		cancel()
+
+		return nil, errors.New("connection reset")
+	}
+
+	rt := client.Adapt(client.RoundTripperFunc(fail), client.Retry(
+		client.WithBackoff(func(int) time.Duration { return 0 }),
+		client.WithRetryIf(func(*http.Response, error) bool { return true }),
+	))
+
+	r, err := http.NewRequestWithContext(ctx, http.MethodGet, "http://x", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := rt.RoundTrip(r); err == nil {
+		t.Error("round trip error was not returned")
+	}
+
+	if n := atomic.LoadInt32(&attempts); n != 1 {
+		t.Errorf("invalid attempts. got: %d, want: %d", n, 1)
+	}
+}
+
+func TestExponentialBackoff(t *testing.T) {
+	t.Parallel()
+
+	b := client.ExponentialBackoff(100*time.Millisecond, time.Second)
+
+	cases := []struct {
+		n        int
+		min, max time.Duration
+	}{
+		{n: 0, min: 50 * time.Millisecond, max: 100 * time.Millisecond},
+		{n: 1, min: 100 * time.Millisecond, max: 200 * time.Millisecond},
+		{n: 3, min: 400 * time.Millisecond, max: 800 * time.Millisecond},
+		{n: 4, min: 500 * time.Millisecond, max: time.Second},
+		{n: 100, min: 500 * time.Millisecond, max: time.Second},
+	}
+
+	for _, c := range cases {
+		for i := 0; i < 10; i++ {
+			if d := b(c.n); d < c.min || d > c.max {
+				t.Errorf(
+					"[%d] invalid backoff. got: %v, want: [%v, %v]",
+					c.n, d, c.min, c.max,
+				)
+			}
+		}
+	}
+}
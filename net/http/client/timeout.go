@@ -0,0 +1,46 @@
+// Copyright 2026 Miguel Angel Rivera Notararigo. All rights reserved.
+// This source code was released under the MIT license.
+
+package client
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"time"
+)
+
+// Timeout sets a time limit for round trips, including reading the response
+// body. When used after Retry, every attempt has its own time limit.
+func Timeout(d time.Duration) Adapter {
+	return func(rt http.RoundTripper) http.RoundTripper {
+		nrt := func(r *http.Request) (*http.Response, error) {
+			ctx, cancel := context.WithTimeout(r.Context(), d)
+
+			res, err := rt.RoundTrip(r.WithContext(ctx))
+			if err != nil {
+				cancel()
+				return nil, err //nolint:wrapcheck
+			}
+
+			res.Body = &cancelBody{ReadCloser: res.Body, cancel: cancel}
+
+			return res, nil
+		}
+
+		return RoundTripperFunc(nrt)
+	}
+}
+
+// cancelBody releases the context of a round trip when closed.
+type cancelBody struct {
+	io.ReadCloser
+	cancel context.CancelFunc
+}
+
+func (b *cancelBody) Close() error {
+	err := b.ReadCloser.Close()
+	b.cancel()
+
+	return err //nolint:wrapcheck
+}
@@ -0,0 +1,51 @@
+// Copyright 2026 Miguel Angel Rivera Notararigo. All rights reserved.
+// This source code was released under the MIT license.
+
+package client_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"go.ntrrg.dev/ntgo/net/http/client"
+)
+
+func TestTimeout(t *testing.T) {
+	t.Parallel()
+
+	s := httptest.NewServer(http.HandlerFunc(
+		func(w http.ResponseWriter, r *http.Request) {
+			if r.URL.Path == "/slow" {
+				select {
+				case <-r.Context().Done():
+				case <-time.After(5 * time.Second):
+				}
+			}
+		},
+	))
+
+	defer s.Close()
+
+	c := &http.Client{
+		Transport: client.Adapt(
+			s.Client().Transport,
+			client.Timeout(50*time.Millisecond),
+		),
+	}
+
+	res, err := c.Get(s.URL + "/fast") //nolint:noctx
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	res.Body.Close()
+
+	_, err = c.Get(s.URL + "/slow") //nolint:noctx,bodyclose
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("invalid error. got: %v, want: %v", err, context.DeadlineExceeded)
+	}
+}
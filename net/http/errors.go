@@ -0,0 +1,36 @@
+// Copyright 2026 Miguel Angel Rivera Notararigo. All rights reserved.
+// This source code was released under the MIT license.
+
+package http
+
+import (
+	"go.ntrrg.dev/ntgo"
+)
+
+// Err is the main error group for this package.
+var Err = ntgo.Err.New("http", "http package errors")
+
+var ErrNotSupported = Err.New(
+	"not-supported",
+	"operation not supported on this platform",
+)
+
+var (
+	ErrListen = Err.New("listen", "cannot listen")
+	ErrServe  = Err.New("serve", "server failure")
+
+	ErrSocketInUse = ErrListen.New("in-use", "socket is in use")
+)
+
+var (
+	ErrHandoff = Err.New("handoff", "cannot hand off listeners")
+	ErrSystemd = Err.New("systemd", "systemd integration failure")
+)
+
+// Shutdown errors.
+var (
+	ErrShutdown = Err.New("shutdown", "cannot shut down gracefully")
+
+	ErrShutdownHook    = ErrShutdown.New("hook", "shutdown hook failure")
+	ErrShutdownTimeout = ErrShutdown.New("timeout", "shutdown deadline exceeded")
+)
@@ -0,0 +1,256 @@
+// Copyright 2026 Miguel Angel Rivera Notararigo. All rights reserved.
+// This source code was released under the MIT license.
+
+package http
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net"
+	"os"
+	"os/exec"
+	"strconv"
+	"strings"
+)
+
+// Environment variables used for passing listeners to new processes. The
+// readiness pipe is passed as the first extra file descriptor (3), followed
+// by the listeners.
+const (
+	handoffFDsEnv   = "NTGO_HANDOFF_FDS"
+	handoffNamesEnv = "NTGO_HANDOFF_FDNAMES"
+)
+
+// handoffReadyMsg is sent through the readiness pipe by new processes.
+const handoffReadyMsg = "READY=1\n"
+
+// WithRestartSignals sets the signals that trigger Restart. There are no
+// restart signals by default.
+//
+//	l := http.NewLifecycle(http.WithRestartSignals(syscall.SIGUSR2))
+func WithRestartSignals(sigs ...os.Signal) LifecycleOption {
+	return func(o *lifecycleOptions) {
+		o.restartSignals = sigs
+	}
+}
+
+// Restart starts a new instance of the program (same executable, arguments
+// and environment) for replacing the running one without dropping
+// connections. The listeners of the servers are passed to the new process,
+// where Run uses them instead of listening again. Once the new process is
+// ready (its servers started), shutdown begins in the running process.
+//
+// Only servers registered with Add, AddTLS, AddUDS and AddInherited have
+// their listeners passed. If the new process exits or ctx is done before it
+// is ready, it is killed and an ErrHandoff error is returned, the running
+// process keeps serving.
+func (l *Lifecycle) Restart(ctx context.Context) error {
+	var (
+		files []*os.File
+		names []string
+	)
+
+	defer func() {
+		for _, f := range files {
+			f.Close()
+		}
+	}()
+
+	for _, ls := range l.servers {
+		if ls.key == "" || ls.ln == nil {
+			continue
+		}
+
+		fl, ok := ls.ln.(interface{ File() (*os.File, error) })
+		if !ok {
+			return ErrHandoff.Wrap(
+				errors.New("cannot get file from '" + ls.key + "' listener"),
+			)
+		}
+
+		f, err := fl.File()
+		if err != nil {
+			return ErrHandoff.Wrap(err)
+		}
+
+		files = append(files, f)
+		names = append(names, ls.key)
+	}
+
+	if err := startHandoff(ctx, files, names); err != nil {
+		return err
+	}
+
+	// The socket files are used by the new process now.
+	for _, ls := range l.servers {
+		if u, ok := ls.ln.(interface{ SetUnlinkOnClose(bool) }); ok {
+			u.SetUnlinkOnClose(false)
+		}
+	}
+
+	l.Shutdown()
+
+	return nil
+}
+
+// startHandoff starts a new instance of the program with files as extra file
+// descriptors, and waits for it to be ready.
+func startHandoff(
+	ctx context.Context,
+	files []*os.File,
+	names []string,
+) error {
+	exe, err := os.Executable()
+	if err != nil {
+		return ErrHandoff.Wrap(err)
+	}
+
+	r, w, err := os.Pipe()
+	if err != nil {
+		return ErrHandoff.Wrap(err)
+	}
+
+	defer r.Close()
+
+	cmd := exec.Command(exe, os.Args[1:]...) //nolint:gosec
+	cmd.Stdin = os.Stdin
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	cmd.ExtraFiles = append([]*os.File{w}, files...)
+
+	cmd.Env = append(
+		os.Environ(),
+		handoffFDsEnv+"="+strconv.Itoa(len(files)),
+		handoffNamesEnv+"="+strings.Join(names, "\n"),
+	)
+
+	err = cmd.Start()
+	w.Close()
+
+	if err != nil {
+		return ErrHandoff.Wrap(err)
+	}
+
+	exited := make(chan struct{})
+
+	go func() {
+		cmd.Wait() //nolint:errcheck
+		close(exited)
+	}()
+
+	ready := make(chan error, 1)
+
+	go func() {
+		buf := make([]byte, len(handoffReadyMsg))
+
+		_, err := io.ReadFull(r, buf)
+		if errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) {
+			err = errors.New("new process exited before being ready")
+		} else if err == nil && string(buf) != handoffReadyMsg {
+			err = errors.New("invalid readiness message " + strconv.Quote(string(buf)))
+		}
+
+		ready <- err
+	}()
+
+	select {
+	case err = <-ready:
+	case <-ctx.Done():
+		err = ctx.Err()
+	}
+
+	if err != nil {
+		cmd.Process.Kill() //nolint:errcheck
+		<-exited
+
+		return ErrHandoff.Wrap(err)
+	}
+
+	return nil
+}
+
+// inheritedListeners returns the listeners passed by a previous process (see
+// Lifecycle.Restart) or by systemd socket activation, grouped by name. If
+// they come from a previous process, the readiness pipe is returned too.
+func inheritedListeners() (map[string][]net.Listener, *os.File, error) {
+	var (
+		ls    []NamedListener
+		ready *os.File
+		err   error
+	)
+
+	if fds, ok := os.LookupEnv(handoffFDsEnv); ok {
+		names := os.Getenv(handoffNamesEnv)
+
+		os.Unsetenv(handoffFDsEnv)
+		os.Unsetenv(handoffNamesEnv)
+
+		n, aerr := strconv.Atoi(fds)
+		if aerr != nil || n < 0 {
+			return nil, nil, ErrHandoff.Wrap(
+				errors.New("invalid " + handoffFDsEnv + " " + fds),
+			)
+		}
+
+		ready = os.NewFile(listenFDsStart, "handoff-ready")
+
+		ls, err = fileListeners(listenFDsStart+1, n, strings.Split(names, "\n"))
+		if err != nil {
+			ready.Close()
+			return nil, nil, ErrHandoff.Wrap(err)
+		}
+
+		for i := range ls {
+			ls[i].Listener = unlinkInherited(ls[i])
+		}
+	} else if ls, err = SystemdListeners(); err != nil {
+		return nil, nil, err
+	}
+
+	m := make(map[string][]net.Listener, len(ls))
+	for _, ln := range ls {
+		m[ln.Name] = append(m[ln.Name], ln.Listener)
+	}
+
+	return m, ready, nil
+}
+
+// unlinkInherited makes ln remove its socket file when closed, as the
+// listener created by the previous process did. Listeners from
+// net.FileListener don't remove it, so it would be left behind once the
+// process stops.
+func unlinkInherited(ln NamedListener) net.Listener {
+	p := strings.TrimPrefix(ln.Name, "unix:")
+	if p == ln.Name || strings.HasPrefix(p, "@") {
+		return ln.Listener
+	}
+
+	u, ok := ln.Listener.(*net.UnixListener)
+	if !ok {
+		return ln.Listener
+	}
+
+	// Sockets with mode or owner are created with a temporary name, see
+	// listenPrivateUDS.
+	if u.Addr().String() != p {
+		return &udsListener{UnixListener: u, path: p, unlink: true}
+	}
+
+	if u, ok := net.Listener(u).(interface{ SetUnlinkOnClose(bool) }); ok {
+		u.SetUnlinkOnClose(true)
+	}
+
+	return u
+}
+
+// notifyHandoff notifies the previous process that the servers started.
+func notifyHandoff(ready *os.File) error {
+	defer ready.Close()
+
+	if _, err := io.WriteString(ready, handoffReadyMsg); err != nil {
+		return ErrHandoff.Wrap(err)
+	}
+
+	return nil
+}
@@ -0,0 +1,150 @@
+// Copyright 2026 Miguel Angel Rivera Notararigo. All rights reserved.
+// This source code was released under the MIT license.
+
+//go:build !js && !plan9 && !windows
+
+package http_test
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	nthttp "go.ntrrg.dev/ntgo/net/http"
+)
+
+// TestMain runs the new process started by Lifecycle.Restart, which uses the
+// same arguments as the test process.
+func TestMain(m *testing.M) {
+	switch os.Getenv("NTGO_TEST_HANDOFF_CHILD") {
+	case "":
+		os.Exit(m.Run())
+	case "fail":
+		os.Exit(1)
+	}
+
+	l := nthttp.NewLifecycle(nthttp.WithShutdownSignals())
+
+	h := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/quit" {
+			l.Shutdown()
+		}
+
+		io.WriteString(w, "child") //nolint:errcheck
+	})
+
+	l.AddUDS(&http.Server{Handler: h}, os.Getenv("NTGO_TEST_HANDOFF_SOCKET"))
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+
+	if err := l.Run(ctx); err != nil {
+		os.Exit(1)
+	}
+}
+
+func TestLifecycle_Restart(t *testing.T) {
+	t.Run("Plain", func(t *testing.T) { testRestart(t) })
+
+	// The socket is created with a temporary name, see WithSocketMode.
+	t.Run("Mode", func(t *testing.T) {
+		testRestart(t, nthttp.WithSocketMode(0o600))
+	})
+}
+
+func testRestart(t *testing.T, opts ...nthttp.UDSOption) {
+	dir, err := os.MkdirTemp("", "ntgo-net-http-handoff")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	defer os.RemoveAll(dir)
+
+	p := filepath.Join(dir, "http.sock")
+	t.Setenv("NTGO_TEST_HANDOFF_SOCKET", p)
+
+	h := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		io.WriteString(w, "parent") //nolint:errcheck
+	})
+
+	l := nthttp.NewLifecycle(nthttp.WithShutdownSignals())
+	l.AddUDS(&http.Server{Handler: h}, p, opts...)
+
+	done := make(chan error, 1)
+
+	go func() { done <- l.Run(context.Background()) }()
+
+	<-l.Ready()
+
+	c := &http.Client{Transport: nthttp.NewUDSTransport(p)}
+
+	get := func(path string) string {
+		t.Helper()
+
+		res, err := c.Get("http://unix" + path) //nolint:noctx
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		defer res.Body.Close()
+
+		body, err := io.ReadAll(res.Body)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		return string(body)
+	}
+
+	if body := get("/"); body != "parent" {
+		t.Fatalf("invalid response. got: %q, want: %q", body, "parent")
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	// Failed restarts.
+	t.Setenv("NTGO_TEST_HANDOFF_CHILD", "fail")
+
+	if err := l.Restart(ctx); !errors.Is(err, nthttp.ErrHandoff) {
+		t.Fatalf("invalid error. got: %v, want: %v", err, nthttp.ErrHandoff)
+	}
+
+	if body := get("/"); body != "parent" {
+		t.Fatalf("invalid response. got: %q, want: %q", body, "parent")
+	}
+
+	t.Setenv("NTGO_TEST_HANDOFF_CHILD", "1")
+
+	if err := l.Restart(ctx); err != nil {
+		t.Fatalf("cannot restart: %v", err)
+	}
+
+	select {
+	case err := <-done:
+		if err != nil {
+			t.Errorf("previous process failed: %v", err)
+		}
+	case <-ctx.Done():
+		t.Fatal("previous process didn't stop")
+	}
+
+	if body := get("/quit"); body != "child" {
+		t.Errorf("invalid response. got: %q, want: %q", body, "child")
+	}
+
+	for i := 0; i < 500; i++ {
+		if _, err := os.Stat(p); errors.Is(err, os.ErrNotExist) {
+			return
+		}
+
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	t.Error("socket file was not removed by the new process")
+}
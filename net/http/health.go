@@ -0,0 +1,344 @@
+// Copyright 2026 Miguel Angel Rivera Notararigo. All rights reserved.
+// This source code was released under the MIT license.
+
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// Health status values.
+const (
+	HealthOK       = "ok"
+	HealthDegraded = "degraded"
+	HealthFail     = "fail"
+	HealthStopping = "stopping"
+)
+
+// Default health check settings.
+const (
+	DefaultCheckTimeout = 5 * time.Second
+	DefaultHealthCache  = time.Second
+)
+
+// Health is a registry of health checks, served as liveness and readiness
+// endpoints.
+//
+// Checks are critical by default, a failing critical check makes the status
+// HealthFail, while failing non-critical checks make it HealthDegraded.
+// Failing statuses are served with http.StatusServiceUnavailable.
+//
+//	h := http.NewHealth(http.WithLifecycle(l))
+//	h.Register("db", db.PingContext)
+//	h.Register("cache", cache.Ping, http.WithNonCritical())
+//
+//	mux.Handle("/livez", h.LivenessHandler())
+//	mux.Handle("/readyz", h.ReadinessHandler())
+type Health struct {
+	o healthOptions
+
+	mu     sync.RWMutex
+	checks []*healthCheck
+}
+
+// NewHealth creates a health registry.
+func NewHealth(opts ...HealthOption) *Health {
+	o := healthOptions{cache: DefaultHealthCache}
+
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	return &Health{o: o}
+}
+
+// Register adds a check named name. fn must return a non-nil error if the
+// component is unhealthy, ctx is done when the check timeout is exceeded.
+// Checks are used for readiness only, unless WithLiveness is given. It panics
+// if name is already registered.
+func (h *Health) Register(
+	name string,
+	fn func(ctx context.Context) error,
+	opts ...CheckOption,
+) {
+	c := &healthCheck{
+		name:     name,
+		fn:       fn,
+		timeout:  DefaultCheckTimeout,
+		critical: true,
+	}
+
+	for _, opt := range opts {
+		opt(c)
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	for _, check := range h.checks {
+		if check.name == name {
+			panic("http: health check '" + name + "' is already registered")
+		}
+	}
+
+	h.checks = append(h.checks, c)
+}
+
+// Liveness runs the liveness checks.
+func (h *Health) Liveness(ctx context.Context) HealthReport {
+	return h.run(ctx, true)
+}
+
+// Readiness runs all the checks. Once shutdown began (see WithLifecycle),
+// checks are not run and the status is HealthStopping.
+func (h *Health) Readiness(ctx context.Context) HealthReport {
+	if h.stopping() {
+		return HealthReport{Status: HealthStopping}
+	}
+
+	return h.run(ctx, false)
+}
+
+// LivenessHandler serves liveness reports as JSON.
+func (h *Health) LivenessHandler() http.Handler {
+	return healthHandler(h.Liveness)
+}
+
+// ReadinessHandler serves readiness reports as JSON.
+func (h *Health) ReadinessHandler() http.Handler {
+	return healthHandler(h.Readiness)
+}
+
+func (h *Health) run(ctx context.Context, liveness bool) HealthReport {
+	h.mu.RLock()
+
+	checks := make([]*healthCheck, 0, len(h.checks))
+	for _, c := range h.checks {
+		if !liveness || c.liveness {
+			checks = append(checks, c)
+		}
+	}
+
+	h.mu.RUnlock()
+
+	results := make([]CheckResult, len(checks))
+
+	var wg sync.WaitGroup
+
+	for i, c := range checks {
+		i, c := i, c
+
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			results[i] = c.result(ctx, h.o.cache)
+		}()
+	}
+
+	wg.Wait()
+
+	r := HealthReport{Status: HealthOK}
+	if len(results) > 0 {
+		r.Checks = make(map[string]CheckResult, len(results))
+	}
+
+	for _, res := range results {
+		r.Checks[res.Name] = res
+
+		if res.Status == HealthOK {
+			continue
+		}
+
+		if res.Critical {
+			r.Status = HealthFail
+		} else if r.Status == HealthOK {
+			r.Status = HealthDegraded
+		}
+	}
+
+	return r
+}
+
+func (h *Health) stopping() bool {
+	if h.o.stopping == nil {
+		return false
+	}
+
+	select {
+	case <-h.o.stopping:
+		return true
+	default:
+		return false
+	}
+}
+
+// HealthReport is the result of running health checks.
+type HealthReport struct {
+	Status string                 `json:"status"`
+	Checks map[string]CheckResult `json:"checks,omitempty"`
+}
+
+// Healthy reports if the status is not failing.
+func (r HealthReport) Healthy() bool {
+	return r.Status == HealthOK || r.Status == HealthDegraded
+}
+
+// CheckResult is the result of a health check.
+type CheckResult struct {
+	Name     string        `json:"-"`
+	Status   string        `json:"status"`
+	Error    string        `json:"error,omitempty"`
+	Critical bool          `json:"critical"`
+	Duration time.Duration `json:"duration"`
+	Time     time.Time     `json:"time"`
+}
+
+// MarshalJSON implements json.Marshaler. Durations are encoded as strings
+// (e.g. "1.5ms").
+func (r CheckResult) MarshalJSON() ([]byte, error) {
+	type result CheckResult
+
+	return json.Marshal(struct { //nolint:wrapcheck
+		result
+		Duration string `json:"duration"`
+	}{result: result(r), Duration: r.Duration.String()})
+}
+
+// CheckOption configures health checks.
+type CheckOption func(*healthCheck)
+
+// WithCheckTimeout sets the maximum duration of the check. Default is
+// DefaultCheckTimeout.
+func WithCheckTimeout(d time.Duration) CheckOption {
+	return func(c *healthCheck) {
+		c.timeout = d
+	}
+}
+
+// WithLiveness uses the check for liveness too.
+func WithLiveness() CheckOption {
+	return func(c *healthCheck) {
+		c.liveness = true
+	}
+}
+
+// WithNonCritical marks the check as non-critical.
+func WithNonCritical() CheckOption {
+	return func(c *healthCheck) {
+		c.critical = false
+	}
+}
+
+type healthCheck struct {
+	name     string
+	fn       func(context.Context) error
+	timeout  time.Duration
+	critical bool
+	liveness bool
+
+	mu   sync.Mutex
+	last CheckResult
+}
+
+// result returns the last result if it is newer than ttl, otherwise the
+// check is run.
+func (c *healthCheck) result(
+	ctx context.Context,
+	ttl time.Duration,
+) CheckResult {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if !c.last.Time.IsZero() && time.Since(c.last.Time) < ttl {
+		return c.last
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, c.timeout)
+	defer cancel()
+
+	start := time.Now()
+	errc := make(chan error, 1)
+
+	go func() { errc <- c.fn(ctx) }()
+
+	var err error
+
+	select {
+	case err = <-errc:
+	case <-ctx.Done():
+		err = ctx.Err()
+	}
+
+	res := CheckResult{
+		Name:     c.name,
+		Status:   HealthOK,
+		Critical: c.critical,
+		Duration: time.Since(start),
+		Time:     start,
+	}
+
+	if err != nil {
+		res.Status = HealthFail
+		res.Error = err.Error()
+	}
+
+	// Results from canceled requests are not cached.
+	if !errors.Is(err, context.Canceled) {
+		c.last = res
+	}
+
+	return res
+}
+
+// HealthOption configures health registries.
+type HealthOption func(*healthOptions)
+
+// WithHealthCache sets how long check results are reused. Default is
+// DefaultHealthCache.
+func WithHealthCache(d time.Duration) HealthOption {
+	return func(o *healthOptions) {
+		o.cache = d
+	}
+}
+
+// WithLifecycle makes readiness fail once l began shutting down, so load
+// balancers stop sending requests while servers drain.
+func WithLifecycle(l *Lifecycle) HealthOption {
+	return func(o *healthOptions) {
+		o.stopping = l.Stopping()
+	}
+}
+
+type healthOptions struct {
+	cache    time.Duration
+	stopping <-chan struct{}
+}
+
+func healthHandler(fn func(context.Context) HealthReport) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		report := fn(r.Context())
+
+		status := http.StatusOK
+		if !report.Healthy() {
+			status = http.StatusServiceUnavailable
+		}
+
+		data, err := json.Marshal(report)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json; charset=utf-8")
+		w.Header().Set("Cache-Control", "no-store")
+		w.WriteHeader(status)
+		w.Write(append(data, '\n')) //nolint:errcheck
+	})
+}
@@ -0,0 +1,147 @@
+// Copyright 2026 Miguel Angel Rivera Notararigo. All rights reserved.
+// This source code was released under the MIT license.
+
+package http_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	nthttp "go.ntrrg.dev/ntgo/net/http"
+)
+
+func TestHealth(t *testing.T) {
+	t.Parallel()
+
+	var calls, failing int32
+
+	l := nthttp.NewLifecycle()
+	h := nthttp.NewHealth(
+		nthttp.WithLifecycle(l),
+		nthttp.WithHealthCache(time.Hour),
+	)
+
+	h.Register("process", func(ctx context.Context) error {
+		return nil
+	}, nthttp.WithLiveness())
+
+	h.Register("db", func(ctx context.Context) error {
+		atomic.AddInt32(&calls, 1)
+
+		if atomic.LoadInt32(&failing) == 1 {
+			return errors.New("connection refused")
+		}
+
+		return nil
+	})
+
+	h.Register("cache", func(ctx context.Context) error {
+		<-ctx.Done()
+		return ctx.Err()
+	}, nthttp.WithNonCritical(), nthttp.WithCheckTimeout(10*time.Millisecond))
+
+	get := func(handler http.Handler) (int, map[string]any) {
+		t.Helper()
+
+		w := httptest.NewRecorder()
+		r := httptest.NewRequest(http.MethodGet, "/", nil)
+		handler.ServeHTTP(w, r)
+
+		ct := w.Header().Get("Content-Type")
+		if ct != "application/json; charset=utf-8" {
+			t.Errorf("invalid content type: %q", ct)
+		}
+
+		var body map[string]any
+		if err := json.Unmarshal(w.Body.Bytes(), &body); err != nil {
+			t.Fatalf("invalid JSON response: %v\n%s", err, w.Body.String())
+		}
+
+		return w.Code, body
+	}
+
+	status, body := get(h.LivenessHandler())
+	if status != http.StatusOK || body["status"] != nthttp.HealthOK {
+		t.Errorf("invalid liveness. got: %d %v", status, body)
+	}
+
+	checks, _ := body["checks"].(map[string]any)
+	if len(checks) != 1 || checks["process"] == nil {
+		t.Errorf("invalid liveness checks: %v", checks)
+	}
+
+	status, body = get(h.ReadinessHandler())
+	if status != http.StatusOK || body["status"] != nthttp.HealthDegraded {
+		t.Errorf("invalid readiness. got: %d %v", status, body)
+	}
+
+	checks, _ = body["checks"].(map[string]any)
+	cache, _ := checks["cache"].(map[string]any)
+
+	if cache["status"] != nthttp.HealthFail || cache["error"] == "" {
+		t.Errorf("invalid check result: %v", cache)
+	}
+
+	if _, ok := cache["duration"].(string); !ok {
+		t.Errorf("invalid check duration: %v", cache["duration"])
+	}
+
+	// Cached results.
+	atomic.StoreInt32(&failing, 1)
+
+	r := h.Readiness(context.Background())
+	if r.Status != nthttp.HealthDegraded {
+		t.Errorf(
+			"invalid status. got: %v, want: %v", r.Status, nthttp.HealthDegraded,
+		)
+	}
+
+	if n := atomic.LoadInt32(&calls); n != 1 {
+		t.Errorf("invalid number of calls. got: %d, want: %d", n, 1)
+	}
+
+	h2 := nthttp.NewHealth(nthttp.WithHealthCache(0))
+	h2.Register("db", func(ctx context.Context) error {
+		return errors.New("connection refused")
+	})
+
+	status, body = get(h2.ReadinessHandler())
+	if status != http.StatusServiceUnavailable ||
+		body["status"] != nthttp.HealthFail {
+		t.Errorf("invalid readiness. got: %d %v", status, body)
+	}
+
+	// Shutdown.
+	l.Shutdown()
+
+	status, body = get(h.ReadinessHandler())
+	if status != http.StatusServiceUnavailable ||
+		body["status"] != nthttp.HealthStopping {
+		t.Errorf("invalid readiness. got: %d %v", status, body)
+	}
+
+	if status, _ := get(h.LivenessHandler()); status != http.StatusOK {
+		t.Errorf("invalid liveness status. got: %d, want: %d", status, 200)
+	}
+}
+
+func TestHealth_duplicate(t *testing.T) {
+	t.Parallel()
+
+	h := nthttp.NewHealth()
+	h.Register("db", func(ctx context.Context) error { return nil })
+
+	defer func() {
+		if recover() == nil {
+			t.Error("check name was reused")
+		}
+	}()
+
+	h.Register("db", func(ctx context.Context) error { return nil })
+}
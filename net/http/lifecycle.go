@@ -0,0 +1,429 @@
+// Copyright 2026 Miguel Angel Rivera Notararigo. All rights reserved.
+// This source code was released under the MIT license.
+
+package http
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"net"
+	"net/http"
+	"os"
+	"os/signal"
+	"sync"
+	"syscall"
+	"time"
+
+	nterrors "go.ntrrg.dev/ntgo/errors"
+)
+
+// DefaultShutdownTimeout is the default time given to servers for finishing
+// their active requests during graceful shutdowns.
+const DefaultShutdownTimeout = 30 * time.Second
+
+// Lifecycle runs multiple servers and gracefully shuts them down.
+//
+// Servers are registered with the Add methods and started by Run, which
+// blocks until every server has stopped. Shutdown begins when a signal is
+// received (SIGINT or SIGTERM by default), when the context given to Run is
+// done, or when any server fails.
+//
+//	l := http.NewLifecycle(http.WithShutdownTimeout(10 * time.Second))
+//	l.Add(&http.Server{Addr: ":8080", Handler: h})
+//	l.AddUDS(&http.Server{Handler: admin}, "/run/app/admin.sock")
+//
+//	l.OnShutdown(func(ctx context.Context) error {
+//		return db.Close()
+//	})
+//
+//	if err := l.Run(context.Background()); err != nil {
+//		log.Fatal(err)
+//	}
+type Lifecycle struct {
+	o lifecycleOptions
+
+	servers []*lifecycleServer
+	hooks   []func(context.Context) error
+
+	ready    chan struct{}
+	stopping chan struct{}
+
+	// handoffReady is used for notifying readiness to the previous process.
+	handoffReady *os.File
+
+	stopOnce sync.Once
+
+	mu   sync.Mutex
+	errs []error
+}
+
+// NewLifecycle creates a lifecycle manager.
+func NewLifecycle(opts ...LifecycleOption) *Lifecycle {
+	o := lifecycleOptions{
+		signals: []os.Signal{os.Interrupt, syscall.SIGTERM},
+		timeout: DefaultShutdownTimeout,
+	}
+
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	return &Lifecycle{
+		o:        o,
+		ready:    make(chan struct{}),
+		stopping: make(chan struct{}),
+	}
+}
+
+// Add registers s for serving HTTP requests over TCP on s.Addr. If
+// s.TLSConfig has certificates, HTTPS is used.
+func (l *Lifecycle) Add(s *http.Server) {
+	key := "tcp:" + tcpAddr(s, hasCertificates(s.TLSConfig))
+
+	l.add(s, key, func() (net.Listener, error) {
+		return listenTCP(s, hasCertificates(s.TLSConfig))
+	}, func(ln net.Listener) error {
+		if hasCertificates(s.TLSConfig) {
+			return s.ServeTLS(ln, "", "")
+		}
+
+		return s.Serve(ln)
+	})
+}
+
+// AddTLS registers s for serving HTTPS requests over TCP on s.Addr, see
+// http.Server.ServeTLS for details about certFile and keyFile.
+func (l *Lifecycle) AddTLS(s *http.Server, certFile, keyFile string) {
+	l.add(s, "tcp:"+tcpAddr(s, true), func() (net.Listener, error) {
+		return listenTCP(s, true)
+	}, func(ln net.Listener) error {
+		return s.ServeTLS(ln, certFile, keyFile)
+	})
+}
+
+// AddUDS registers s for serving HTTP requests over a UNIX Domain Socket on
+// p, see ListenAndServeUDS for details.
+func (l *Lifecycle) AddUDS(s *http.Server, p string, opts ...UDSOption) {
+	l.add(s, "unix:"+p, func() (net.Listener, error) {
+		return listenUDS(p, opts...)
+	}, s.Serve)
+}
+
+// AddListener registers s for serving HTTP requests from ln. ln is not passed
+// to new processes by Restart, see AddInherited.
+func (l *Lifecycle) AddListener(s *http.Server, ln net.Listener) {
+	l.add(s, "", func() (net.Listener, error) {
+		return ln, nil
+	}, s.Serve)
+}
+
+// AddInherited registers s for serving HTTP requests from the inherited
+// listener named name, which may come from systemd socket activation (see
+// SystemdListeners) or from a previous process (see Restart). If there is no
+// such listener, Run fails with an ErrListen error.
+func (l *Lifecycle) AddInherited(s *http.Server, name string) {
+	l.add(s, name, func() (net.Listener, error) {
+		return nil, errors.New("no inherited listener named '" + name + "'")
+	}, s.Serve)
+}
+
+// OnShutdown registers fn for being called after all the servers stopped.
+// Hooks are called in registration order, even if previous hooks failed. ctx
+// is done when the shutdown deadline is exceeded.
+func (l *Lifecycle) OnShutdown(fn func(ctx context.Context) error) {
+	l.hooks = append(l.hooks, fn)
+}
+
+// Ready returns a channel that is closed when all the servers started.
+func (l *Lifecycle) Ready() <-chan struct{} {
+	return l.ready
+}
+
+// Stopping returns a channel that is closed when shutdown begins.
+func (l *Lifecycle) Stopping() <-chan struct{} {
+	return l.stopping
+}
+
+// Run starts all the servers and blocks until they stopped and shutdown hooks
+// were called. Run must not be called more than once.
+//
+// Listeners inherited from a previous process (see Restart) or from systemd
+// socket activation are used when possible, see AddInherited. Activation
+// variables are removed from the environment, see SystemdListeners.
+//
+// If any server can't listen, the other servers are not started and an
+// ErrListen error is returned. Otherwise, errors from servers (ErrServe),
+// shutdown (ErrShutdown, ErrShutdownTimeout) and hooks (ErrShutdownHook) are
+// reported as an error group (see go.ntrrg.dev/ntgo/errors.Group).
+func (l *Lifecycle) Run(ctx context.Context) error {
+	if err := l.listen(); err != nil {
+		return err
+	}
+
+	sigs := make(chan os.Signal, 1)
+	if len(l.o.signals) > 0 {
+		signal.Notify(sigs, l.o.signals...)
+		defer signal.Stop(sigs)
+	}
+
+	restartSigs := make(chan os.Signal, 1)
+	if len(l.o.restartSignals) > 0 {
+		signal.Notify(restartSigs, l.o.restartSignals...)
+		defer signal.Stop(restartSigs)
+	}
+
+	var wg sync.WaitGroup
+
+	failed := make(chan struct{}, len(l.servers))
+
+	for _, ls := range l.servers {
+		ls := ls
+
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			err := ls.serve(ls.ln)
+			if err == nil || errors.Is(err, http.ErrServerClosed) {
+				return
+			}
+
+			l.fail(ErrServe.Wrap(err))
+			failed <- struct{}{}
+		}()
+	}
+
+	close(l.ready)
+
+	if l.handoffReady != nil {
+		if err := notifyHandoff(l.handoffReady); err != nil {
+			l.fail(err)
+		}
+	}
+
+	if l.o.systemd {
+		if _, err := SystemdNotify(SystemdReady); err != nil {
+			l.fail(err)
+		}
+
+		go systemdWatchdog(l.stopping, l.fail)
+	}
+
+wait:
+	for {
+		select {
+		case <-ctx.Done():
+		case <-sigs:
+		case <-failed:
+		case <-l.stopping:
+		case <-restartSigs:
+			rctx, cancel := context.WithTimeout(ctx, l.o.timeout)
+
+			if err := l.Restart(rctx); err != nil {
+				l.fail(err)
+			}
+
+			cancel()
+
+			continue
+		}
+
+		break wait
+	}
+
+	l.shutdown()
+	wg.Wait()
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if len(l.errs) > 0 {
+		return nterrors.Group(l.errs...)
+	}
+
+	return nil
+}
+
+// listen creates the listeners of the servers. Inherited listeners are used
+// when available.
+func (l *Lifecycle) listen() error {
+	inherited, ready, err := inheritedListeners()
+	if err != nil {
+		return ErrListen.Wrap(err)
+	}
+
+	l.handoffReady = ready
+
+	var lerr error
+
+	for _, ls := range l.servers {
+		if lns := inherited[ls.key]; ls.key != "" && len(lns) > 0 {
+			ls.ln, inherited[ls.key] = lns[0], lns[1:]
+			continue
+		}
+
+		if ls.ln, lerr = ls.listen(); lerr != nil {
+			break
+		}
+	}
+
+	for _, lns := range inherited {
+		for _, ln := range lns {
+			ln.Close()
+		}
+	}
+
+	if lerr == nil {
+		return nil
+	}
+
+	for _, ls := range l.servers {
+		if ls.ln != nil {
+			ls.ln.Close()
+		}
+	}
+
+	if !errors.Is(lerr, ErrListen) {
+		lerr = ErrListen.Wrap(lerr)
+	}
+
+	return lerr
+}
+
+// Shutdown begins the graceful shutdown of a running lifecycle, it doesn't
+// wait for servers to stop.
+func (l *Lifecycle) Shutdown() {
+	l.stopOnce.Do(func() { close(l.stopping) })
+}
+
+func (l *Lifecycle) shutdown() {
+	l.Shutdown()
+
+	if l.o.systemd {
+		if _, err := SystemdNotify(SystemdStopping); err != nil {
+			l.fail(err)
+		}
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), l.o.timeout)
+	defer cancel()
+
+	var wg sync.WaitGroup
+
+	for _, ls := range l.servers {
+		ls := ls
+
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			err := ls.s.Shutdown(ctx)
+			if err == nil {
+				return
+			}
+
+			if errors.Is(err, context.DeadlineExceeded) {
+				err = ErrShutdownTimeout.Wrap(err)
+				ls.s.Close()
+			} else {
+				err = ErrShutdown.Wrap(err)
+			}
+
+			l.fail(err)
+		}()
+	}
+
+	wg.Wait()
+
+	for _, fn := range l.hooks {
+		if err := fn(ctx); err != nil {
+			l.fail(ErrShutdownHook.Wrap(err))
+		}
+	}
+}
+
+// fail records err for being reported by Run.
+func (l *Lifecycle) fail(err error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.errs = append(l.errs, err)
+}
+
+func (l *Lifecycle) add(
+	s *http.Server,
+	key string,
+	listen func() (net.Listener, error),
+	serve func(net.Listener) error,
+) {
+	l.servers = append(l.servers, &lifecycleServer{
+		s:      s,
+		key:    key,
+		listen: listen,
+		serve:  serve,
+	})
+}
+
+type lifecycleServer struct {
+	s *http.Server
+
+	// key identifies the listener of the server for restarts, servers with
+	// an empty key don't inherit listeners.
+	key string
+
+	ln     net.Listener
+	listen func() (net.Listener, error)
+	serve  func(net.Listener) error
+}
+
+// LifecycleOption configures lifecycle managers.
+type LifecycleOption func(*lifecycleOptions)
+
+// WithShutdownSignals sets the signals that begin shutdown. Default is
+// SIGINT and SIGTERM, calling it without arguments disables signals.
+func WithShutdownSignals(sigs ...os.Signal) LifecycleOption {
+	return func(o *lifecycleOptions) {
+		o.signals = sigs
+	}
+}
+
+// WithShutdownTimeout sets the time given to servers for finishing their
+// active requests and to shutdown hooks. Servers that exceed it are closed
+// forcefully. Default is DefaultShutdownTimeout.
+func WithShutdownTimeout(d time.Duration) LifecycleOption {
+	return func(o *lifecycleOptions) {
+		o.timeout = d
+	}
+}
+
+type lifecycleOptions struct {
+	restartSignals []os.Signal
+	signals        []os.Signal
+	systemd        bool
+	timeout        time.Duration
+}
+
+func hasCertificates(c *tls.Config) bool {
+	return c != nil && (len(c.Certificates) > 0 || c.GetCertificate != nil)
+}
+
+// listenTCP listens on the address of s, see tcpAddr.
+func listenTCP(s *http.Server, secure bool) (net.Listener, error) {
+	return net.Listen("tcp", tcpAddr(s, secure)) //nolint:wrapcheck
+}
+
+// tcpAddr returns s.Addr, or the default HTTP or HTTPS port if it is empty.
+func tcpAddr(s *http.Server, secure bool) string {
+	if s.Addr != "" {
+		return s.Addr
+	}
+
+	if secure {
+		return ":https"
+	}
+
+	return ":http"
+}
@@ -0,0 +1,199 @@
+// Copyright 2026 Miguel Angel Rivera Notararigo. All rights reserved.
+// This source code was released under the MIT license.
+
+package http_test
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	nthttp "go.ntrrg.dev/ntgo/net/http"
+)
+
+func TestLifecycle(t *testing.T) {
+	t.Parallel()
+
+	dir, err := os.MkdirTemp("", "ntgo-net-http-lifecycle")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	defer os.RemoveAll(dir)
+
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	started := make(chan struct{})
+	release := make(chan struct{})
+
+	h := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/slow" {
+			close(started)
+			<-release
+		}
+
+		io.WriteString(w, "ok") //nolint:errcheck
+	})
+
+	l := nthttp.NewLifecycle(nthttp.WithShutdownSignals())
+	l.AddListener(&http.Server{Handler: h}, ln)
+	l.AddUDS(&http.Server{Handler: h}, filepath.Join(dir, "http.sock"))
+
+	var hooks []int
+
+	for i := 1; i <= 3; i++ {
+		i := i
+
+		l.OnShutdown(func(ctx context.Context) error {
+			hooks = append(hooks, i)
+
+			if i == 2 {
+				return errors.New("hook failure")
+			}
+
+			return nil
+		})
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	done := make(chan error)
+
+	go func() { done <- l.Run(ctx) }()
+
+	url := "http://" + ln.Addr().String()
+	slow := make(chan error)
+
+	go func() {
+		res, err := http.Get(url + "/slow") //nolint:noctx
+		if err != nil {
+			slow <- err
+			return
+		}
+
+		defer res.Body.Close()
+
+		_, err = io.ReadAll(res.Body)
+		slow <- err
+	}()
+
+	<-started
+	cancel()
+
+	select {
+	case <-l.Stopping():
+	case <-time.After(5 * time.Second):
+		t.Fatal("shutdown didn't begin")
+	}
+
+	select {
+	case err := <-done:
+		t.Fatalf("servers stopped before finishing requests: %v", err)
+	case <-time.After(100 * time.Millisecond):
+	}
+
+	close(release)
+
+	if err := <-slow; err != nil {
+		t.Errorf("active request failed: %v", err)
+	}
+
+	err = <-done
+	if !errors.Is(err, nthttp.ErrShutdownHook) {
+		t.Errorf("invalid error. got: %v, want: %v", err, nthttp.ErrShutdownHook)
+	}
+
+	if len(hooks) != 3 || hooks[0] != 1 || hooks[1] != 2 || hooks[2] != 3 {
+		t.Errorf("invalid hooks order. got: %v, want: %v", hooks, []int{1, 2, 3})
+	}
+}
+
+func TestLifecycle_timeout(t *testing.T) {
+	t.Parallel()
+
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	started := make(chan struct{})
+	release := make(chan struct{})
+
+	defer close(release)
+
+	h := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		close(started)
+		<-release
+	})
+
+	l := nthttp.NewLifecycle(
+		nthttp.WithShutdownSignals(),
+		nthttp.WithShutdownTimeout(50*time.Millisecond),
+	)
+
+	l.AddListener(&http.Server{Handler: h}, ln)
+
+	done := make(chan error)
+
+	go func() { done <- l.Run(context.Background()) }()
+
+	go func() {
+		res, err := http.Get("http://" + ln.Addr().String()) //nolint:noctx
+		if err == nil {
+			res.Body.Close()
+		}
+	}()
+
+	<-started
+	l.Shutdown()
+
+	err = <-done
+	if !errors.Is(err, nthttp.ErrShutdownTimeout) {
+		t.Errorf("invalid error. got: %v, want: %v", err, nthttp.ErrShutdownTimeout)
+	}
+}
+
+func TestLifecycle_errors(t *testing.T) {
+	t.Parallel()
+
+	l := nthttp.NewLifecycle(nthttp.WithShutdownSignals())
+	l.AddUDS(&http.Server{}, filepath.Join("missing", "dir", "http.sock"))
+
+	err := l.Run(context.Background())
+	if !errors.Is(err, nthttp.ErrListen) {
+		t.Errorf("invalid error. got: %v, want: %v", err, nthttp.ErrListen)
+	}
+
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ln.Close()
+
+	l = nthttp.NewLifecycle(nthttp.WithShutdownSignals())
+	l.AddListener(&http.Server{}, ln)
+
+	done := make(chan error)
+
+	go func() { done <- l.Run(context.Background()) }()
+
+	select {
+	case err := <-done:
+		if !errors.Is(err, nthttp.ErrServe) {
+			t.Errorf("invalid error. got: %v, want: %v", err, nthttp.ErrServe)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("server failure didn't stop the lifecycle")
+	}
+}
@@ -0,0 +1,16 @@
+// Copyright 2026 Miguel Angel Rivera Notararigo. All rights reserved.
+// This source code was released under the MIT license.
+
+//go:build !plan9
+
+package http
+
+import (
+	"errors"
+	"syscall"
+)
+
+// isConnRefused reports if err is caused by a refused connection.
+func isConnRefused(err error) bool {
+	return errors.Is(err, syscall.ECONNREFUSED)
+}
@@ -0,0 +1,13 @@
+// Copyright 2026 Miguel Angel Rivera Notararigo. All rights reserved.
+// This source code was released under the MIT license.
+
+package http
+
+import (
+	"strings"
+)
+
+// isConnRefused reports if err is caused by a refused connection.
+func isConnRefused(err error) bool {
+	return strings.Contains(err.Error(), "connection refused")
+}
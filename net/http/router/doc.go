@@ -0,0 +1,31 @@
+// Copyright 2026 Miguel Angel Rivera Notararigo. All rights reserved.
+// This source code was released under the MIT license.
+
+/*
+Package router provides an HTTP request multiplexer with method matching, path
+parameters and route groups. Handlers are wrapped with middleware.Adapter
+chains, so any adapter from the middleware package may be used per group.
+
+	r := router.New()
+	r.HandleFunc(http.MethodGet, "/", index)
+
+	api := r.Group("/api", middleware.JSONResponse())
+	api.HandleFunc(http.MethodGet, "/users/{id}", getUser).Name("user")
+	api.HandleFunc(http.MethodGet, "/files/{path...}", getFile)
+
+	u, err := r.URL("user", "id", "42") // "/api/users/42"
+
+Patterns are matched segment by segment, static segments take precedence over
+parameters ({name}), and parameters over wildcards ({name...}), which match
+the rest of the path and must be the last segment. If several patterns match
+a path, the most specific one with a handler for the request method is used.
+Parameter values are obtained with Param.
+
+When a path matches but the method doesn't, a 405 response with an Allow
+header listing the methods of every matching pattern is sent. HEAD requests
+are served by GET handlers and OPTIONS requests get the Allow header, unless
+specific handlers are registered for them.
+*/
+package router
+
+// API Status: testing
@@ -0,0 +1,19 @@
+// Copyright 2026 Miguel Angel Rivera Notararigo. All rights reserved.
+// This source code was released under the MIT license.
+
+package router
+
+import (
+	nthttp "go.ntrrg.dev/ntgo/net/http"
+)
+
+// Err is the main error group for this package.
+var Err = nthttp.Err.New("router", "router package errors")
+
+// URL building errors.
+var (
+	ErrURL = Err.New("url", "cannot build URL")
+
+	ErrUnknownRoute = ErrURL.New("unknown-route", "unknown route")
+	ErrMissingParam = ErrURL.New("missing-param", "missing route parameter")
+)
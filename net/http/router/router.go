@@ -0,0 +1,521 @@
+// Copyright 2026 Miguel Angel Rivera Notararigo. All rights reserved.
+// This source code was released under the MIT license.
+
+package router
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+	"sync"
+
+	"go.ntrrg.dev/ntgo/net/http/middleware"
+)
+
+// Router is an HTTP request multiplexer. Routers created with Group share
+// their routes with the router they come from, but have their own prefix and
+// adapters.
+type Router struct {
+	t        *tree
+	prefix   string
+	adapters []middleware.Adapter
+}
+
+// New creates a router.
+func New(opts ...Option) *Router {
+	o := options{
+		notFound:         http.NotFoundHandler(),
+		methodNotAllowed: http.HandlerFunc(methodNotAllowed),
+	}
+
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	t := &tree{
+		o:     o,
+		root:  new(node),
+		named: make(map[string]*Route),
+	}
+
+	return &Router{t: t}
+}
+
+// Group creates a router for registering routes under prefix. Its routes are
+// wrapped with the adapters of rt, followed by a.
+func (rt *Router) Group(prefix string, a ...middleware.Adapter) *Router {
+	adapters := make([]middleware.Adapter, 0, len(rt.adapters)+len(a))
+	adapters = append(adapters, rt.adapters...)
+	adapters = append(adapters, a...)
+
+	return &Router{
+		t:        rt.t,
+		prefix:   rt.prefix + strings.TrimSuffix(prefix, "/"),
+		adapters: adapters,
+	}
+}
+
+// Handle registers h for requests with the given method and a path matching
+// pattern. If method is empty, any method is matched. It panics if pattern is
+// invalid or if it is already registered for method.
+func (rt *Router) Handle(method, pattern string, h http.Handler) *Route {
+	pattern = rt.prefix + pattern
+
+	segs, err := parsePattern(pattern)
+	if err != nil {
+		panic("router: " + err.Error())
+	}
+
+	r := &Route{
+		method:  method,
+		pattern: pattern,
+		segs:    segs,
+		h:       middleware.Adapt(h, rt.adapters...),
+		t:       rt.t,
+	}
+
+	rt.t.add(r)
+
+	return r
+}
+
+// HandleFunc works as Handle but for http.HandlerFunc.
+func (rt *Router) HandleFunc(
+	method, pattern string,
+	h func(w http.ResponseWriter, r *http.Request),
+) *Route {
+	return rt.Handle(method, pattern, http.HandlerFunc(h))
+}
+
+// ServeHTTP implements http.Handler.
+func (rt *Router) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	var (
+		route  *Route
+		values []string
+		allow  string
+	)
+
+	rt.t.mu.RLock()
+
+	matches := rt.t.root.lookup(splitPath(r.URL), nil, nil)
+	for _, m := range matches {
+		if route = m.n.route(r.Method); route != nil {
+			values = m.values
+			break
+		}
+	}
+
+	if route == nil && len(matches) > 0 {
+		allow = allowed(matches)
+	}
+
+	rt.t.mu.RUnlock()
+
+	switch {
+	case len(matches) == 0:
+		rt.t.o.notFound.ServeHTTP(w, r)
+	case route != nil:
+		p := &params{route: route, values: values}
+		ctx := context.WithValue(r.Context(), paramsKey{}, p)
+		route.h.ServeHTTP(w, r.WithContext(ctx))
+	case r.Method == http.MethodOptions:
+		w.Header().Set("Allow", allow)
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		w.Header().Set("Allow", allow)
+		rt.t.o.methodNotAllowed.ServeHTTP(w, r)
+	}
+}
+
+// URL builds the path of the route named name. params are key-value pairs
+// with the values of the route parameters, they are escaped.
+//
+//	rt.HandleFunc(http.MethodGet, "/files/{path...}", getFile).Name("file")
+//	u, err := rt.URL("file", "path", "docs/my notes.md")
+//	// "/files/docs/my%20notes.md"
+func (rt *Router) URL(name string, params ...string) (string, error) {
+	rt.t.mu.RLock()
+	r, ok := rt.t.named[name]
+	rt.t.mu.RUnlock()
+
+	if !ok {
+		return "", ErrUnknownRoute.Wrap(errors.New("'" + name + "'"))
+	}
+
+	return r.URL(params...)
+}
+
+// Param returns the value of the parameter name from the route that matched
+// r. An empty string is returned if there is no such parameter.
+func Param(r *http.Request, name string) string {
+	p, _ := r.Context().Value(paramsKey{}).(*params)
+	if p == nil {
+		return ""
+	}
+
+	i := 0
+
+	for _, s := range p.route.segs {
+		if s.kind == staticSegment {
+			continue
+		}
+
+		if s.value == name {
+			return p.values[i]
+		}
+
+		i++
+	}
+
+	return ""
+}
+
+// Route is a registered route.
+type Route struct {
+	method  string
+	pattern string
+	segs    []segment
+	h       http.Handler
+	t       *tree
+}
+
+// Method returns the method matched by r, an empty string means any method.
+func (r *Route) Method() string {
+	return r.method
+}
+
+// Name sets the name used for building URLs with Router.URL. It panics if
+// name is used by another route.
+func (r *Route) Name(name string) *Route {
+	r.t.mu.Lock()
+	defer r.t.mu.Unlock()
+
+	if other, ok := r.t.named[name]; ok && other != r {
+		panic("router: route name '" + name + "' is already used")
+	}
+
+	r.t.named[name] = r
+
+	return r
+}
+
+// Pattern returns the pattern of r, including group prefixes.
+func (r *Route) Pattern() string {
+	return r.pattern
+}
+
+// URL builds the path of r, see Router.URL.
+func (r *Route) URL(params ...string) (string, error) {
+	if len(params)%2 != 0 {
+		return "", ErrURL.Wrap(errors.New("odd number of parameters"))
+	}
+
+	var b strings.Builder
+
+	for _, s := range r.segs {
+		b.WriteByte('/')
+
+		if s.kind == staticSegment {
+			b.WriteString(url.PathEscape(s.value))
+			continue
+		}
+
+		v, ok := lookupParam(params, s.value)
+		if !ok || (v == "" && s.kind == paramSegment) {
+			return "", ErrMissingParam.Wrap(errors.New("'" + s.value + "'"))
+		}
+
+		if s.kind == paramSegment {
+			b.WriteString(url.PathEscape(v))
+			continue
+		}
+
+		for i, part := range strings.Split(v, "/") {
+			if i > 0 {
+				b.WriteByte('/')
+			}
+
+			b.WriteString(url.PathEscape(part))
+		}
+	}
+
+	return b.String(), nil
+}
+
+// Option configures routers.
+type Option func(*options)
+
+// WithMethodNotAllowed sets the handler used when a path matches, but not
+// its method. The Allow header is set before calling h. Default is a plain
+// text 405 response.
+func WithMethodNotAllowed(h http.Handler) Option {
+	return func(o *options) {
+		o.methodNotAllowed = h
+	}
+}
+
+// WithNotFound sets the handler used when no route matches. Default is
+// http.NotFoundHandler.
+func WithNotFound(h http.Handler) Option {
+	return func(o *options) {
+		o.notFound = h
+	}
+}
+
+type options struct {
+	notFound         http.Handler
+	methodNotAllowed http.Handler
+}
+
+type tree struct {
+	o options
+
+	mu    sync.RWMutex
+	root  *node
+	named map[string]*Route
+}
+
+func (t *tree) add(r *Route) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	n := t.root
+	for _, s := range r.segs {
+		n = n.child(s)
+	}
+
+	if n.routes == nil {
+		n.routes = make(map[string]*Route)
+	}
+
+	if _, ok := n.routes[r.method]; ok {
+		panic(
+			"router: pattern '" + r.pattern + "' is already registered for " +
+				"method '" + r.method + "'",
+		)
+	}
+
+	n.routes[r.method] = r
+}
+
+type node struct {
+	static   map[string]*node
+	param    *node
+	wildcard *node
+	routes   map[string]*Route
+}
+
+func (n *node) child(s segment) *node {
+	switch s.kind {
+	case paramSegment:
+		if n.param == nil {
+			n.param = new(node)
+		}
+
+		return n.param
+	case wildcardSegment:
+		if n.wildcard == nil {
+			n.wildcard = new(node)
+		}
+
+		return n.wildcard
+	}
+
+	if n.static == nil {
+		n.static = make(map[string]*node)
+	}
+
+	c, ok := n.static[s.value]
+	if !ok {
+		c = new(node)
+		n.static[s.value] = c
+	}
+
+	return c
+}
+
+// lookup appends the nodes matching segs to matches, static segments take
+// precedence over parameters, and parameters over wildcards, so more specific
+// nodes are appended first. Parameter values are appended to values.
+func (n *node) lookup(segs, values []string, matches []match) []match {
+	if len(segs) == 0 {
+		if len(n.routes) == 0 {
+			return matches
+		}
+
+		return append(matches, match{n: n, values: copyValues(values)})
+	}
+
+	if c, ok := n.static[segs[0]]; ok {
+		matches = c.lookup(segs[1:], values, matches)
+	}
+
+	if n.param != nil && segs[0] != "" {
+		matches = n.param.lookup(segs[1:], append(values, segs[0]), matches)
+	}
+
+	if n.wildcard != nil && len(n.wildcard.routes) > 0 {
+		values = append(values, strings.Join(segs, "/"))
+		matches = append(matches, match{n: n.wildcard, values: copyValues(values)})
+	}
+
+	return matches
+}
+
+func (n *node) route(method string) *Route {
+	if r, ok := n.routes[method]; ok {
+		return r
+	}
+
+	if method == http.MethodHead {
+		if r, ok := n.routes[http.MethodGet]; ok {
+			return r
+		}
+	}
+
+	return n.routes[""]
+}
+
+// match is a node matching a path, with the values of its parameters.
+type match struct {
+	n      *node
+	values []string
+}
+
+type paramsKey struct{}
+
+type params struct {
+	route  *Route
+	values []string
+}
+
+type segmentKind int
+
+const (
+	staticSegment segmentKind = iota
+	paramSegment
+	wildcardSegment
+)
+
+type segment struct {
+	kind  segmentKind
+	value string
+}
+
+// allowed returns the value of the Allow header for the methods of matches.
+func allowed(matches []match) string {
+	set := make(map[string]bool)
+
+	for _, m := range matches {
+		for method := range m.n.routes {
+			set[method] = true
+		}
+	}
+
+	if set[http.MethodGet] {
+		set[http.MethodHead] = true
+	}
+
+	set[http.MethodOptions] = true
+
+	methods := make([]string, 0, len(set))
+	for m := range set {
+		methods = append(methods, m)
+	}
+
+	sort.Strings(methods)
+
+	return strings.Join(methods, ", ")
+}
+
+// copyValues copies values, so matches don't share their backing array.
+func copyValues(values []string) []string {
+	return append([]string(nil), values...)
+}
+
+func lookupParam(params []string, name string) (string, bool) {
+	for i := 0; i < len(params); i += 2 {
+		if params[i] == name {
+			return params[i+1], true
+		}
+	}
+
+	return "", false
+}
+
+func methodNotAllowed(w http.ResponseWriter, r *http.Request) {
+	code := http.StatusMethodNotAllowed
+	http.Error(w, http.StatusText(code), code)
+}
+
+func parsePattern(p string) ([]segment, error) {
+	if !strings.HasPrefix(p, "/") {
+		return nil, errors.New("pattern '" + p + "' must start with '/'")
+	}
+
+	parts := strings.Split(p[1:], "/")
+	segs := make([]segment, len(parts))
+	names := make(map[string]bool)
+
+	for i, part := range parts {
+		s := segment{kind: staticSegment, value: part}
+
+		if !strings.HasPrefix(part, "{") || !strings.HasSuffix(part, "}") {
+			if strings.ContainsAny(part, "{}") {
+				return nil, errors.New(
+					"invalid segment '" + part + "' in pattern '" + p + "'",
+				)
+			}
+
+			segs[i] = s
+
+			continue
+		}
+
+		s.kind = paramSegment
+		s.value = part[1 : len(part)-1]
+
+		if strings.HasSuffix(s.value, "...") {
+			s.kind = wildcardSegment
+			s.value = strings.TrimSuffix(s.value, "...")
+
+			if i != len(parts)-1 {
+				return nil, errors.New(
+					"wildcard '" + part + "' must be the last segment of '" + p + "'",
+				)
+			}
+		}
+
+		if s.value == "" || strings.ContainsAny(s.value, "{}") || names[s.value] {
+			return nil, errors.New(
+				"invalid parameter '" + part + "' in pattern '" + p + "'",
+			)
+		}
+
+		names[s.value] = true
+		segs[i] = s
+	}
+
+	return segs, nil
+}
+
+// splitPath returns the unescaped segments of the path of u.
+func splitPath(u *url.URL) []string {
+	p := u.EscapedPath()
+	if !strings.HasPrefix(p, "/") {
+		return nil
+	}
+
+	segs := strings.Split(p[1:], "/")
+
+	for i, s := range segs {
+		if us, err := url.PathUnescape(s); err == nil {
+			segs[i] = us
+		}
+	}
+
+	return segs
+}
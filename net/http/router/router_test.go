@@ -0,0 +1,287 @@
+// Copyright 2026 Miguel Angel Rivera Notararigo. All rights reserved.
+// This source code was released under the MIT license.
+
+package router_test
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"go.ntrrg.dev/ntgo/net/http/middleware"
+	"go.ntrrg.dev/ntgo/net/http/router"
+)
+
+func ExampleRouter() {
+	r := router.New()
+
+	r.HandleFunc(
+		http.MethodGet, "/users/{id}",
+		func(w http.ResponseWriter, r *http.Request) {
+			fmt.Fprintf(w, "user %s", router.Param(r, "id"))
+		},
+	).Name("user")
+
+	u, _ := r.URL("user", "id", "42")
+
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, u, nil))
+
+	fmt.Println(u)
+	fmt.Println(w.Body.String())
+	// Output:
+	// /users/42
+	// user 42
+}
+
+func TestRouter(t *testing.T) {
+	t.Parallel()
+
+	r := router.New()
+
+	h := func(name string, params ...string) http.HandlerFunc {
+		return func(w http.ResponseWriter, r *http.Request) {
+			io.WriteString(w, name) //nolint:errcheck
+
+			for _, p := range params {
+				io.WriteString(w, " "+p+"="+router.Param(r, p)) //nolint:errcheck
+			}
+		}
+	}
+
+	r.Handle(http.MethodGet, "/", h("index"))
+	r.Handle(http.MethodGet, "/users", h("users"))
+	r.Handle(http.MethodPost, "/users", h("create-user"))
+	r.Handle(http.MethodGet, "/users/me", h("me"))
+	r.Handle(http.MethodGet, "/users/{id}", h("user", "id"))
+	r.Handle(http.MethodDelete, "/users/{id}", h("delete-user", "id"))
+	r.Handle(http.MethodGet, "/users/{id}/posts/{post}", h("post", "id", "post"))
+	r.Handle(http.MethodGet, "/files/{path...}", h("file", "path"))
+	r.Handle(http.MethodPost, "/files/upload", h("upload"))
+	r.Handle(http.MethodOptions, "/cors", h("cors"))
+	r.Handle("", "/any", h("any"))
+
+	cases := []struct {
+		method, path string
+		status       int
+		body, allow  string
+	}{
+		{method: "GET", path: "/", status: 200, body: "index"},
+		{method: "GET", path: "/users", status: 200, body: "users"},
+		{method: "POST", path: "/users", status: 200, body: "create-user"},
+		{method: "GET", path: "/users/me", status: 200, body: "me"},
+		{method: "GET", path: "/users/42", status: 200, body: "user id=42"},
+		{
+			method: "GET", path: "/users/a%2Fb", status: 200,
+			body: "user id=a/b",
+		},
+		{
+			method: "DELETE", path: "/users/42", status: 200,
+			body: "delete-user id=42",
+		},
+		{
+			method: "GET", path: "/users/42/posts/1", status: 200,
+			body: "post id=42 post=1",
+		},
+		{
+			method: "GET", path: "/files/docs/index.html", status: 200,
+			body: "file path=docs/index.html",
+		},
+		{method: "GET", path: "/files/", status: 200, body: "file path="},
+		{
+			method: "DELETE", path: "/users/me", status: 200,
+			body: "delete-user id=me",
+		},
+		{method: "POST", path: "/files/upload", status: 200, body: "upload"},
+		{
+			method: "GET", path: "/files/upload", status: 200,
+			body: "file path=upload",
+		},
+		{method: "HEAD", path: "/users", status: 200, body: "users"},
+		{method: "OPTIONS", path: "/cors", status: 200, body: "cors"},
+		{method: "PATCH", path: "/any", status: 200, body: "any"},
+		{method: "GET", path: "/users/", status: 404},
+		{method: "GET", path: "/files", status: 404},
+		{method: "GET", path: "/users/42/posts", status: 404},
+		{method: "GET", path: "/unknown", status: 404},
+		{
+			method: "PUT", path: "/users/42", status: 405,
+			allow: "DELETE, GET, HEAD, OPTIONS",
+		},
+		{
+			method: "PUT", path: "/users/me", status: 405,
+			allow: "DELETE, GET, HEAD, OPTIONS",
+		},
+		{
+			method: "PUT", path: "/files/upload", status: 405,
+			allow: "GET, HEAD, OPTIONS, POST",
+		},
+		{
+			method: "OPTIONS", path: "/users", status: 204,
+			allow: "GET, HEAD, OPTIONS, POST",
+		},
+		{method: "GET", path: "/cors", status: 405, allow: "OPTIONS"},
+	}
+
+	for _, c := range cases {
+		label := c.method + " " + c.path
+
+		w := httptest.NewRecorder()
+		req := httptest.NewRequest(c.method, c.path, nil)
+		r.ServeHTTP(w, req)
+
+		if w.Code != c.status {
+			t.Errorf("[%s] invalid status. got: %d, want: %d", label, w.Code, c.status)
+			continue
+		}
+
+		if c.body != "" && w.Body.String() != c.body {
+			t.Errorf("[%s] invalid body. got: %q, want: %q", label, w.Body, c.body)
+		}
+
+		if allow := w.Header().Get("Allow"); allow != c.allow {
+			t.Errorf("[%s] invalid Allow. got: %q, want: %q", label, allow, c.allow)
+		}
+	}
+}
+
+func TestRouter_Group(t *testing.T) {
+	t.Parallel()
+
+	mark := func(s string) middleware.Adapter {
+		return func(h http.Handler) http.Handler {
+			return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				io.WriteString(w, s) //nolint:errcheck
+				h.ServeHTTP(w, r)
+			})
+		}
+	}
+
+	h := func(w http.ResponseWriter, r *http.Request) {
+		io.WriteString(w, "h") //nolint:errcheck
+	}
+
+	r := router.New(
+		router.WithNotFound(http.HandlerFunc(
+			func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusNotFound)
+				io.WriteString(w, "not found") //nolint:errcheck
+			},
+		)),
+	)
+
+	r.HandleFunc(http.MethodGet, "/", h)
+
+	api := r.Group("/api/", mark("a"))
+	api.HandleFunc(http.MethodGet, "/", h)
+
+	v1 := api.Group("/v1", mark("b"), mark("c"))
+	v1.HandleFunc(http.MethodGet, "/users", h).Name("users")
+
+	cases := map[string]string{
+		"/":             "h",
+		"/api/":         "ah",
+		"/api/v1/users": "abch",
+		"/api/v1":       "not found",
+	}
+
+	for path, want := range cases {
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, path, nil))
+
+		if got := w.Body.String(); got != want {
+			t.Errorf("[%s] invalid body. got: %q, want: %q", path, got, want)
+		}
+	}
+
+	u, err := r.URL("users")
+	if err != nil || u != "/api/v1/users" {
+		t.Errorf("invalid URL. got: %q (%v), want: %q", u, err, "/api/v1/users")
+	}
+}
+
+func TestRouter_URL(t *testing.T) {
+	t.Parallel()
+
+	h := func(w http.ResponseWriter, r *http.Request) {}
+
+	r := router.New()
+	r.HandleFunc(http.MethodGet, "/users/{id}", h).Name("user")
+	r.HandleFunc(http.MethodGet, "/files/{path...}", h).Name("file")
+
+	cases := []struct {
+		name   string
+		params []string
+		want   string
+		err    error
+	}{
+		{name: "user", params: []string{"id", "42"}, want: "/users/42"},
+		{name: "user", params: []string{"id", "a/b"}, want: "/users/a%2Fb"},
+		{
+			name: "file", params: []string{"path", "docs/my notes.md"},
+			want: "/files/docs/my%20notes.md",
+		},
+		{name: "file", params: []string{"path", ""}, want: "/files/"},
+		{name: "user", err: router.ErrMissingParam},
+		{name: "user", params: []string{"id", ""}, err: router.ErrMissingParam},
+		{name: "user", params: []string{"id"}, err: router.ErrURL},
+		{name: "unknown", err: router.ErrUnknownRoute},
+	}
+
+	for _, c := range cases {
+		label := c.name + " " + strings.Join(c.params, ",")
+
+		got, err := r.URL(c.name, c.params...)
+		if !errors.Is(err, c.err) {
+			t.Errorf("[%s] invalid error. got: %v, want: %v", label, err, c.err)
+			continue
+		}
+
+		if got != c.want {
+			t.Errorf("[%s] invalid URL. got: %q, want: %q", label, got, c.want)
+		}
+	}
+}
+
+func TestRouter_Handle_invalid(t *testing.T) {
+	t.Parallel()
+
+	h := func(w http.ResponseWriter, r *http.Request) {}
+
+	r := router.New()
+	r.HandleFunc(http.MethodGet, "/users/{id}", h).Name("user")
+
+	cases := []string{
+		"users",
+		"/users/{id}",
+		"/users/{uid}",
+		"/users/id{id}",
+		"/users/{}",
+		"/users/{id}/{id}",
+		"/files/{path...}/info",
+	}
+
+	for _, pattern := range cases {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("[%s] pattern was registered", pattern)
+				}
+			}()
+
+			r.HandleFunc(http.MethodGet, pattern, h)
+		}()
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Error("route name was reused")
+		}
+	}()
+
+	r.HandleFunc(http.MethodGet, "/posts/{id}", h).Name("user")
+}
@@ -5,18 +5,30 @@ package http
 
 import (
 	"context"
-	"fmt"
-	"net"
+	"log"
 	"net/http"
 	"os"
 	"os/signal"
 )
 
 // ListenAndServeUDS listens for HTTP requests over a UNIX Domain Socket on p.
-func ListenAndServeUDS(s *http.Server, p string) error {
-	uds, err := net.Listen("unix", p)
+// The socket file is removed when the server is closed. Listening failures
+// are reported as ErrListen errors.
+//
+// If WithSocketMode or WithSocketOwner are given, the socket is created in a
+// private directory and moved to p after setting them, so it is never
+// accessible with other permissions. Existing files at p are not replaced.
+//
+// On Linux, paths starting with "@" are sockets in the abstract namespace,
+// which don't have a file, so file related options (WithRemoveStale,
+// WithSocketMode and WithSocketOwner) are ignored for them. ErrNotSupported
+// is returned on other platforms.
+//
+// See NewUDSTransport for creating clients.
+func ListenAndServeUDS(s *http.Server, p string, opts ...UDSOption) error {
+	uds, err := listenUDS(p, opts...)
 	if err != nil {
-		return fmt.Errorf("cannot create socket: %w", err)
+		return err
 	}
 
 	return s.Serve(uds) //nolint:wrapcheck
@@ -24,7 +36,10 @@ func ListenAndServeUDS(s *http.Server, p string) error {
 
 // ShutdownServerOn listen for sig signal and gracefully shuts down the given
 // server, ctx is a function that provides a context to be used during server
-// shutdown.
+// shutdown. Shutdown errors are logged with s.ErrorLog, or the standard logger
+// if it is nil.
+//
+// See Lifecycle for managing multiple servers and waiting for them to stop.
 func ShutdownServerOn(
 	s *http.Server,
 	sig os.Signal,
@@ -36,6 +51,11 @@ func ShutdownServerOn(
 		<-c
 
 		if err := s.Shutdown(ctx()); err != nil {
+			if s.ErrorLog == nil {
+				log.Print(err)
+				return
+			}
+
 			s.ErrorLog.Print(err)
 		}
 	}()
@@ -0,0 +1,189 @@
+// Copyright 2026 Miguel Angel Rivera Notararigo. All rights reserved.
+// This source code was released under the MIT license.
+
+package http
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Systemd notification states, see sd_notify(3) for details.
+const (
+	SystemdReady     = "READY=1"
+	SystemdReloading = "RELOADING=1"
+	SystemdStopping  = "STOPPING=1"
+	SystemdWatchdog  = "WATCHDOG=1"
+)
+
+// listenFDsStart is the first file descriptor passed by socket activation.
+const listenFDsStart = 3
+
+// NamedListener is a listener with the name given by its creator.
+type NamedListener struct {
+	net.Listener
+	Name string
+}
+
+// SystemdListeners returns the listeners passed by systemd socket activation
+// (LISTEN_FDS, LISTEN_FDNAMES and LISTEN_PID environment variables), in the
+// order they were passed. If the process was not socket activated, no
+// listeners are returned. Names are taken from FileDescriptorName in the
+// socket unit.
+//
+// Activation variables are removed from the environment, so they are not
+// inherited by child processes. Failures are reported as ErrSystemd errors.
+//
+//	ls, err := http.SystemdListeners()
+//	if err != nil {
+//		return err
+//	}
+//
+//	for _, ln := range ls {
+//		l.AddListener(&http.Server{Handler: h}, ln)
+//	}
+func SystemdListeners() ([]NamedListener, error) {
+	pid := os.Getenv("LISTEN_PID")
+	fds := os.Getenv("LISTEN_FDS")
+	names := os.Getenv("LISTEN_FDNAMES")
+
+	os.Unsetenv("LISTEN_PID")
+	os.Unsetenv("LISTEN_FDS")
+	os.Unsetenv("LISTEN_FDNAMES")
+
+	if pid == "" || fds == "" {
+		return nil, nil
+	}
+
+	if pid != strconv.Itoa(os.Getpid()) {
+		return nil, nil
+	}
+
+	n, err := strconv.Atoi(fds)
+	if err != nil || n < 0 {
+		return nil, ErrSystemd.Wrap(errors.New("invalid LISTEN_FDS " + fds))
+	}
+
+	ls, err := fileListeners(listenFDsStart, n, strings.Split(names, ":"))
+	if err != nil {
+		return nil, ErrSystemd.Wrap(err)
+	}
+
+	return ls, nil
+}
+
+// fileListeners creates n listeners from the file descriptors starting at
+// first. Missing names are set to "unknown", as systemd does.
+func fileListeners(first, n int, names []string) ([]NamedListener, error) {
+	ls := make([]NamedListener, 0, n)
+
+	for i := 0; i < n; i++ {
+		name := "unknown"
+		if i < len(names) && names[i] != "" {
+			name = names[i]
+		}
+
+		f := os.NewFile(uintptr(first+i), name)
+
+		// net.FileListener duplicates the file descriptor.
+		ln, err := net.FileListener(f)
+		f.Close()
+
+		if err != nil {
+			for _, l := range ls {
+				l.Close()
+			}
+
+			return nil, fmt.Errorf("%s: %w", name, err)
+		}
+
+		ls = append(ls, NamedListener{Listener: ln, Name: name})
+	}
+
+	return ls, nil
+}
+
+// SystemdNotify sends state to the service manager through the socket at
+// NOTIFY_SOCKET, see sd_notify(3) for details. Multiple states may be
+// separated by new lines. It reports false if NOTIFY_SOCKET is not defined.
+func SystemdNotify(state string) (bool, error) {
+	p := os.Getenv("NOTIFY_SOCKET")
+	if p == "" {
+		return false, nil
+	}
+
+	conn, err := net.DialUnix("unixgram", nil, &net.UnixAddr{
+		Name: p,
+		Net:  "unixgram",
+	})
+
+	if err != nil {
+		return false, ErrSystemd.Wrap(err)
+	}
+
+	defer conn.Close()
+
+	if _, err := conn.Write([]byte(state)); err != nil {
+		return false, ErrSystemd.Wrap(err)
+	}
+
+	return true, nil
+}
+
+// SystemdWatchdogInterval returns the interval at which the service manager
+// expects SystemdWatchdog notifications, from the WATCHDOG_USEC and
+// WATCHDOG_PID environment variables. It reports false if the watchdog is
+// not enabled for this process.
+func SystemdWatchdogInterval() (time.Duration, bool) {
+	usec, err := strconv.ParseInt(os.Getenv("WATCHDOG_USEC"), 10, 64)
+	if err != nil || usec <= 0 {
+		return 0, false
+	}
+
+	if pid := os.Getenv("WATCHDOG_PID"); pid != "" &&
+		pid != strconv.Itoa(os.Getpid()) {
+		return 0, false
+	}
+
+	return time.Duration(usec) * time.Microsecond, true
+}
+
+// WithSystemd integrates lifecycle managers with systemd. Readiness is
+// notified after all servers started, stopping is notified when shutdown
+// begins, and watchdog notifications are sent at half the watchdog interval
+// while servers are running. It has no effect when the process is not
+// managed by systemd.
+//
+// Notification failures are reported by Lifecycle.Run as ErrSystemd errors.
+func WithSystemd() LifecycleOption {
+	return func(o *lifecycleOptions) {
+		o.systemd = true
+	}
+}
+
+// systemdWatchdog sends watchdog notifications until stop is closed.
+func systemdWatchdog(stop <-chan struct{}, errFn func(error)) {
+	d, ok := SystemdWatchdogInterval()
+	if !ok {
+		return
+	}
+
+	t := time.NewTicker(d / 2)
+	defer t.Stop()
+
+	for {
+		select {
+		case <-stop:
+			return
+		case <-t.C:
+			if _, err := SystemdNotify(SystemdWatchdog); err != nil {
+				errFn(err)
+			}
+		}
+	}
+}
@@ -0,0 +1,188 @@
+// Copyright 2026 Miguel Angel Rivera Notararigo. All rights reserved.
+// This source code was released under the MIT license.
+
+//go:build !js && !plan9 && !windows
+
+package http_test
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	nthttp "go.ntrrg.dev/ntgo/net/http"
+)
+
+func TestSystemdListeners(t *testing.T) {
+	if os.Getenv("NTGO_TEST_SYSTEMD_CHILD") == "1" {
+		systemdChild()
+		return
+	}
+
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	defer ln.Close()
+
+	f, err := ln.(*net.TCPListener).File()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	defer f.Close()
+
+	cmd := exec.Command(os.Args[0], "-test.run=^TestSystemdListeners$")
+	cmd.ExtraFiles = []*os.File{f}
+	cmd.Env = append(
+		os.Environ(),
+		"NTGO_TEST_SYSTEMD_CHILD=1",
+		"LISTEN_FDS=1",
+		"LISTEN_FDNAMES=web",
+	)
+
+	out, err := cmd.CombinedOutput()
+	if err != nil {
+		t.Fatalf("child process failed: %v\n%s", err, out)
+	}
+
+	want := "web " + ln.Addr().String()
+	if !strings.Contains(string(out), want) {
+		t.Errorf("invalid listeners. got: %q, want: %q", out, want)
+	}
+
+	t.Setenv("LISTEN_PID", "1")
+	t.Setenv("LISTEN_FDS", "1")
+
+	ls, err := nthttp.SystemdListeners()
+	if err != nil || len(ls) > 0 {
+		t.Errorf("listeners from other processes were used: %v, %v", ls, err)
+	}
+
+	if _, ok := os.LookupEnv("LISTEN_FDS"); ok {
+		t.Error("activation variables were not removed")
+	}
+}
+
+// systemdChild prints the inherited listeners. LISTEN_PID is set here since
+// the parent can't know the child PID before starting it.
+func systemdChild() {
+	os.Setenv("LISTEN_PID", strconv.Itoa(os.Getpid()))
+
+	ls, err := nthttp.SystemdListeners()
+	if err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
+
+	for _, ln := range ls {
+		fmt.Println(ln.Name, ln.Addr())
+		ln.Close()
+	}
+}
+
+func TestWithSystemd(t *testing.T) {
+	dir, err := os.MkdirTemp("", "ntgo-net-http-systemd")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	defer os.RemoveAll(dir)
+
+	p := filepath.Join(dir, "notify.sock")
+
+	conn, err := net.ListenUnixgram("unixgram", &net.UnixAddr{
+		Name: p,
+		Net:  "unixgram",
+	})
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	defer conn.Close()
+
+	t.Setenv("NOTIFY_SOCKET", p)
+	t.Setenv("WATCHDOG_USEC", "20000")
+	t.Setenv("WATCHDOG_PID", strconv.Itoa(os.Getpid()))
+
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	l := nthttp.NewLifecycle(
+		nthttp.WithShutdownSignals(),
+		nthttp.WithSystemd(),
+	)
+
+	l.AddListener(&http.Server{}, ln)
+
+	done := make(chan error)
+
+	go func() { done <- l.Run(context.Background()) }()
+
+	read := func() string {
+		t.Helper()
+
+		buf := make([]byte, 256)
+
+		conn.SetReadDeadline(time.Now().Add(5 * time.Second)) //nolint:errcheck
+
+		n, err := conn.Read(buf)
+		if err != nil {
+			t.Fatalf("cannot read notification: %v", err)
+		}
+
+		return string(buf[:n])
+	}
+
+	if state := read(); state != nthttp.SystemdReady {
+		t.Errorf("invalid state. got: %q, want: %q", state, nthttp.SystemdReady)
+	}
+
+	if state := read(); state != nthttp.SystemdWatchdog {
+		t.Errorf("invalid state. got: %q, want: %q", state, nthttp.SystemdWatchdog)
+	}
+
+	l.Shutdown()
+
+	if err := <-done; err != nil {
+		t.Errorf("lifecycle failed: %v", err)
+	}
+
+	for {
+		state := read()
+		if state == nthttp.SystemdStopping {
+			break
+		}
+
+		if state != nthttp.SystemdWatchdog {
+			t.Fatalf("invalid state. got: %q, want: %q", state, nthttp.SystemdStopping)
+		}
+	}
+}
+
+func TestSystemdNotify_unset(t *testing.T) {
+	t.Setenv("NOTIFY_SOCKET", "")
+
+	ok, err := nthttp.SystemdNotify(nthttp.SystemdReady)
+	if ok || err != nil {
+		t.Errorf("notification was sent: %v, %v", ok, err)
+	}
+
+	t.Setenv("WATCHDOG_USEC", "")
+
+	if d, ok := nthttp.SystemdWatchdogInterval(); ok {
+		t.Errorf("invalid watchdog interval. got: %v, want: disabled", d)
+	}
+}
@@ -0,0 +1,220 @@
+// Copyright 2026 Miguel Angel Rivera Notararigo. All rights reserved.
+// This source code was released under the MIT license.
+
+package http
+
+import (
+	"context"
+	"errors"
+	"io/fs"
+	"net"
+	"net/http"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"sync"
+	"time"
+)
+
+// UDSOption configures UNIX Domain Socket listeners.
+type UDSOption func(*udsOptions)
+
+// WithRemoveStale removes the socket file if it exists and nothing is
+// listening on it. Sockets in use are reported as ErrSocketInUse, and files
+// that are not sockets are never removed.
+func WithRemoveStale() UDSOption {
+	return func(o *udsOptions) {
+		o.removeStale = true
+	}
+}
+
+// WithSocketMode sets the permissions of the socket file.
+func WithSocketMode(perm fs.FileMode) UDSOption {
+	return func(o *udsOptions) {
+		o.mode = perm
+		o.setMode = true
+	}
+}
+
+// WithSocketOwner sets the owner and group of the socket file, -1 means not
+// changing the value. See os.Chown for details.
+func WithSocketOwner(uid, gid int) UDSOption {
+	return func(o *udsOptions) {
+		o.uid = uid
+		o.gid = gid
+		o.setOwner = true
+	}
+}
+
+type udsOptions struct {
+	removeStale bool
+
+	mode    fs.FileMode
+	setMode bool
+
+	uid, gid int
+	setOwner bool
+}
+
+// listenUDS listens on the UNIX Domain Socket p. Paths starting with "@" are
+// sockets in the Linux abstract namespace, they don't have a file, so file
+// options are ignored.
+//
+// The socket file is removed when the listener is closed.
+func listenUDS(p string, opts ...UDSOption) (net.Listener, error) {
+	var o udsOptions
+
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	abstract := strings.HasPrefix(p, "@")
+
+	if abstract && runtime.GOOS != "linux" && runtime.GOOS != "android" {
+		return nil, ErrNotSupported
+	}
+
+	if o.removeStale && !abstract {
+		if err := removeStaleSocket(p); err != nil {
+			return nil, err
+		}
+	}
+
+	if !abstract && (o.setMode || o.setOwner) {
+		return listenPrivateUDS(p, o)
+	}
+
+	ln, err := net.Listen("unix", p)
+	if err != nil {
+		return nil, ErrListen.Wrap(err)
+	}
+
+	return ln, nil
+}
+
+// listenPrivateUDS creates the socket in a private directory, where it can't
+// be used until its mode and owner are set, and then links it to p.
+func listenPrivateUDS(p string, o udsOptions) (net.Listener, error) {
+	dir, err := os.MkdirTemp(filepath.Dir(p), ".uds-*")
+	if err != nil {
+		return nil, ErrListen.Wrap(err)
+	}
+
+	defer os.RemoveAll(dir)
+
+	tmp := filepath.Join(dir, "sock")
+
+	ln, err := net.ListenUnix("unix", &net.UnixAddr{Name: tmp, Net: "unix"})
+	if err != nil {
+		return nil, ErrListen.Wrap(err)
+	}
+
+	// The temporary file is removed with dir.
+	if u, ok := net.Listener(ln).(interface{ SetUnlinkOnClose(bool) }); ok {
+		u.SetUnlinkOnClose(false)
+	}
+
+	if o.setMode {
+		if err := os.Chmod(tmp, o.mode); err != nil {
+			ln.Close()
+			return nil, ErrListen.Wrap(err)
+		}
+	}
+
+	if o.setOwner {
+		if err := os.Chown(tmp, o.uid, o.gid); err != nil {
+			ln.Close()
+			return nil, ErrListen.Wrap(err)
+		}
+	}
+
+	// Unlike os.Rename, os.Link doesn't replace existing files.
+	if err := os.Link(tmp, p); err != nil {
+		ln.Close()
+		return nil, ErrListen.Wrap(err)
+	}
+
+	return &udsListener{UnixListener: ln, path: p, unlink: true}, nil
+}
+
+// udsListener is a listener whose socket file is p, but was created with a
+// different name.
+type udsListener struct {
+	*net.UnixListener
+
+	path       string
+	unlink     bool
+	unlinkOnce sync.Once
+}
+
+func (l *udsListener) Addr() net.Addr {
+	return &net.UnixAddr{Name: l.path, Net: "unix"}
+}
+
+func (l *udsListener) Close() error {
+	err := l.UnixListener.Close()
+
+	if l.unlink {
+		l.unlinkOnce.Do(func() {
+			os.Remove(l.path)
+		})
+	}
+
+	return err //nolint:wrapcheck
+}
+
+func (l *udsListener) SetUnlinkOnClose(unlink bool) {
+	l.unlink = unlink
+}
+
+// removeStaleSocket removes the socket file at p if nothing is listening on
+// it.
+func removeStaleSocket(p string) error {
+	fi, err := os.Lstat(p)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil
+	} else if err != nil {
+		return ErrListen.Wrap(err)
+	}
+
+	if fi.Mode()&fs.ModeSocket == 0 {
+		return ErrListen.Wrap(errors.New("'" + p + "' is not a socket"))
+	}
+
+	conn, err := net.DialTimeout("unix", p, time.Second)
+	if err == nil {
+		conn.Close()
+		return ErrSocketInUse
+	}
+
+	if !isConnRefused(err) {
+		return ErrListen.Wrap(err)
+	}
+
+	if err := os.Remove(p); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return ErrListen.Wrap(err)
+	}
+
+	return nil
+}
+
+// NewUDSTransport creates a transport that sends every request over the UNIX
+// Domain Socket p, ignoring the request host. Other settings are copied from
+// http.DefaultTransport.
+//
+//	c := &http.Client{Transport: http.NewUDSTransport("/run/app/http.sock")}
+//	res, err := c.Get("http://app/status")
+func NewUDSTransport(p string) *http.Transport {
+	t, _ := http.DefaultTransport.(*http.Transport)
+	t = t.Clone()
+
+	var d net.Dialer
+
+	t.Proxy = nil
+	t.DialContext = func(ctx context.Context, _, _ string) (net.Conn, error) {
+		return d.DialContext(ctx, "unix", p)
+	}
+
+	return t
+}
@@ -0,0 +1,216 @@
+// Copyright 2026 Miguel Angel Rivera Notararigo. All rights reserved.
+// This source code was released under the MIT license.
+
+//go:build !plan9
+
+package http_test
+
+import (
+	"context"
+	"errors"
+	"io"
+	"io/fs"
+	"net"
+	"net/http"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strconv"
+	"testing"
+	"time"
+
+	nthttp "go.ntrrg.dev/ntgo/net/http"
+)
+
+func TestListenAndServeUDS(t *testing.T) {
+	t.Parallel()
+
+	if runtime.GOOS == "windows" {
+		t.Skip("file permissions are not supported")
+	}
+
+	dir, err := os.MkdirTemp("", "ntgo-net-http-uds")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	defer os.RemoveAll(dir)
+
+	p := filepath.Join(dir, "http.sock")
+
+	// Stale socket.
+	ln, err := net.Listen("unix", p)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ln.(*net.UnixListener).SetUnlinkOnClose(false)
+	ln.Close()
+
+	s := &http.Server{Handler: http.HandlerFunc(
+		func(w http.ResponseWriter, r *http.Request) {
+			io.WriteString(w, "ok") //nolint:errcheck
+		},
+	)}
+
+	if err := nthttp.ListenAndServeUDS(s, p); !errors.Is(err, nthttp.ErrListen) {
+		t.Fatalf("invalid error. got: %v, want: %v", err, nthttp.ErrListen)
+	}
+
+	done := make(chan error)
+
+	go func() {
+		done <- nthttp.ListenAndServeUDS(
+			s, p,
+			nthttp.WithRemoveStale(),
+			nthttp.WithSocketMode(0o600),
+			nthttp.WithSocketOwner(-1, -1),
+		)
+	}()
+
+	c := &http.Client{Transport: nthttp.NewUDSTransport(p)}
+	get(t, c, done)
+
+	fi, err := os.Stat(p)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if perm := fi.Mode().Perm(); perm != 0o600 {
+		t.Errorf("invalid permissions. got: %v, want: %v", perm, fs.FileMode(0o600))
+	}
+
+	// Socket in use.
+	err = nthttp.ListenAndServeUDS(&http.Server{}, p, nthttp.WithRemoveStale())
+	if !errors.Is(err, nthttp.ErrSocketInUse) {
+		t.Errorf("invalid error. got: %v, want: %v", err, nthttp.ErrSocketInUse)
+	}
+
+	if err := s.Shutdown(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := <-done; !errors.Is(err, http.ErrServerClosed) {
+		t.Errorf("invalid error. got: %v, want: %v", err, http.ErrServerClosed)
+	}
+
+	if _, err := os.Lstat(p); !errors.Is(err, fs.ErrNotExist) {
+		t.Errorf("socket file was not removed: %v", err)
+	}
+
+	// Regular files.
+	if err := os.WriteFile(p, nil, 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	err = nthttp.ListenAndServeUDS(&http.Server{}, p, nthttp.WithRemoveStale())
+	if !errors.Is(err, nthttp.ErrListen) {
+		t.Errorf("invalid error. got: %v, want: %v", err, nthttp.ErrListen)
+	}
+
+	if _, err := os.Stat(p); err != nil {
+		t.Errorf("regular file was removed: %v", err)
+	}
+
+	err = nthttp.ListenAndServeUDS(
+		&http.Server{}, p, nthttp.WithSocketMode(0o600),
+	)
+
+	if !errors.Is(err, nthttp.ErrListen) {
+		t.Errorf("invalid error. got: %v, want: %v", err, nthttp.ErrListen)
+	}
+
+	if fi, err := os.Lstat(p); err != nil || !fi.Mode().IsRegular() {
+		t.Errorf("regular file was replaced: %v", err)
+	}
+
+	if err := os.Remove(p); err != nil {
+		t.Fatal(err)
+	}
+
+	// Owner errors.
+	if os.Getuid() != 0 {
+		err = nthttp.ListenAndServeUDS(
+			&http.Server{}, p, nthttp.WithSocketOwner(0, 0),
+		)
+
+		if !errors.Is(err, nthttp.ErrListen) {
+			t.Errorf("invalid error. got: %v, want: %v", err, nthttp.ErrListen)
+		}
+
+		if _, err := os.Lstat(p); !errors.Is(err, fs.ErrNotExist) {
+			t.Errorf("socket file was not removed: %v", err)
+		}
+	}
+
+	// Temporary files.
+	if entries, err := os.ReadDir(dir); err != nil || len(entries) > 0 {
+		t.Errorf("temporary files were not removed: %v (%v)", entries, err)
+	}
+}
+
+func TestListenAndServeUDS_abstract(t *testing.T) {
+	t.Parallel()
+
+	p := "@ntgo-net-http-" + strconv.Itoa(os.Getpid())
+
+	s := &http.Server{Handler: http.HandlerFunc(
+		func(w http.ResponseWriter, r *http.Request) {
+			io.WriteString(w, "ok") //nolint:errcheck
+		},
+	)}
+
+	done := make(chan error, 1)
+
+	go func() {
+		done <- nthttp.ListenAndServeUDS(s, p, nthttp.WithRemoveStale())
+	}()
+
+	if runtime.GOOS != "linux" && runtime.GOOS != "android" {
+		if err := <-done; !errors.Is(err, nthttp.ErrNotSupported) {
+			t.Errorf("invalid error. got: %v, want: %v", err, nthttp.ErrNotSupported)
+		}
+
+		return
+	}
+
+	c := &http.Client{Transport: nthttp.NewUDSTransport(p)}
+	get(t, c, done)
+
+	if err := s.Shutdown(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// get requests "/" until the server responds.
+func get(t *testing.T, c *http.Client, done <-chan error) {
+	t.Helper()
+
+	deadline := time.Now().Add(5 * time.Second)
+
+	for {
+		res, err := c.Get("http://unix/") //nolint:noctx
+		if err == nil {
+			body, _ := io.ReadAll(res.Body)
+			res.Body.Close()
+
+			if string(body) != "ok" {
+				t.Errorf("invalid response. got: %q, want: %q", body, "ok")
+			}
+
+			return
+		}
+
+		select {
+		case err := <-done:
+			t.Fatalf("server failure: %v", err)
+		default:
+		}
+
+		if time.Now().After(deadline) {
+			t.Fatalf("cannot connect to server: %v", err)
+		}
+
+		time.Sleep(10 * time.Millisecond)
+	}
+}
@@ -0,0 +1,685 @@
+// Copyright 2026 Miguel Angel Rivera Notararigo. All rights reserved.
+// This source code was released under the MIT license.
+
+package os
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"compress/gzip"
+	"errors"
+	"io"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+)
+
+var (
+	errUnknownFormat    = errors.New("unknown archive format")
+	errUnsupportedEntry = errors.New("unsupported entry type")
+)
+
+// ArchiveFormat is a file format for archives.
+type ArchiveFormat int
+
+const (
+	// Tar is the tape archive format.
+	Tar ArchiveFormat = iota
+
+	// TarGzip is the tape archive format compressed with gzip.
+	TarGzip
+
+	// Zip is the ZIP file format.
+	Zip
+)
+
+// Pack writes the content of the src directory into w as an archive. Entry
+// names are relative to src.
+//
+// WithFilter, WithSymlinks, WithUmask are supported, other copy options are
+// ignored.
+func Pack(w io.Writer, src string, f ArchiveFormat, opts ...CopyOption) error {
+	o := newCopyOptions(opts)
+
+	var aw archiveWriter
+
+	switch f {
+	case Tar:
+		aw = newTarWriter(w, false)
+	case TarGzip:
+		aw = newTarWriter(w, true)
+	case Zip:
+		aw = &zipWriter{w: zip.NewWriter(w)}
+	default:
+		return NewCopyError(ErrCopyArchive, src, "", errUnknownFormat)
+	}
+
+	if err := packDir(aw, src, "", o); err != nil {
+		aw.Close()
+		return err
+	}
+
+	if err := aw.Close(); err != nil {
+		return NewCopyError(ErrCopyWrite, src, "", err)
+	}
+
+	return nil
+}
+
+// Unpack extracts an archive from r into the dst directory, which will be
+// created if it doesn't exist. Zip archives need random access, if r doesn't
+// implement io.ReaderAt, its content is buffered into a temporary file.
+//
+// Entries with absolute paths, parent directory elements ("..") or links
+// pointing outside dst are refused, as well as entries that would be written
+// through symbolic links or replace directories. Link targets are resolved
+// against the file system, so links from previous entries are taken into
+// account.
+//
+// WithFilter, WithSymlinks, WithTimes and WithUmask are supported, other copy
+// options are ignored. Since links can't be followed before they are
+// extracted, SymlinkFollow and SymlinkPreserve have the same effect.
+func Unpack(
+	dst string,
+	r io.Reader,
+	f ArchiveFormat,
+	opts ...CopyOption,
+) error {
+	x := &extractor{dst: dst, opts: newCopyOptions(opts)}
+
+	if err := os.MkdirAll(dst, x.opts.perm(0o755)); err != nil {
+		return NewCopyError(ErrCopyMkdir, "", dst, err)
+	}
+
+	switch f {
+	case Tar:
+		return x.extractTar(r)
+	case TarGzip:
+		gz, err := gzip.NewReader(r)
+		if err != nil {
+			return NewCopyError(ErrCopyArchive, "", dst, err)
+		}
+
+		defer gz.Close()
+
+		return x.extractTar(gz)
+	case Zip:
+		return x.extractZip(r)
+	}
+
+	return NewCopyError(ErrCopyArchive, "", dst, errUnknownFormat)
+}
+
+func packDir(aw archiveWriter, src, prefix string, o copyOptions) error {
+	fn := func(srcpath string, fi os.FileInfo, err error) error {
+		if err != nil {
+			return NewCopyError(ErrCopyStat, srcpath, "", err)
+		}
+
+		if srcpath == src {
+			return nil
+		}
+
+		name := prefix + relPath(src, srcpath)
+
+		if !o.include(name, fi) {
+			if fi.IsDir() {
+				return filepath.SkipDir
+			}
+
+			return nil
+		}
+
+		return packEntry(aw, srcpath, name, fi, o)
+	}
+
+	return filepath.Walk(src, fn) //nolint:wrapcheck
+}
+
+func packEntry(
+	aw archiveWriter,
+	src, name string,
+	fi os.FileInfo,
+	o copyOptions,
+) error {
+	var (
+		link     string
+		followed bool
+	)
+
+	if fi.Mode()&os.ModeSymlink != 0 {
+		var err error
+
+		switch o.symlinks {
+		case SymlinkSkip:
+			return nil
+		case SymlinkPreserve:
+			link, err = os.Readlink(src)
+		default:
+			var target string
+
+			target, fi, o, err = followSymlink(src, o)
+			if err == nil {
+				src = target
+			}
+
+			followed = true
+		}
+
+		if err != nil {
+			return NewCopyError(ErrCopySymlink, src, name, err)
+		}
+	}
+
+	w, err := aw.WriteHeader(name, fi, link, o.perm(fi.Mode()))
+	if err != nil {
+		return NewCopyError(ErrCopyWrite, src, name, err)
+	}
+
+	switch {
+	case fi.IsDir() && followed:
+		// filepath.Walk doesn't walk symbolic links.
+		return packDir(aw, src, name+"/", o)
+	case fi.IsDir(), link != "", !fi.Mode().IsRegular():
+		return nil
+	}
+
+	return packFile(w, src, name)
+}
+
+func packFile(w io.Writer, src, name string) error {
+	f, err := os.Open(src)
+	if err != nil {
+		return NewCopyError(ErrCopyOpenSrc, src, name, err)
+	}
+
+	defer f.Close()
+
+	if _, err := io.Copy(w, f); err != nil {
+		return NewCopyError(ErrCopyWrite, src, name, err)
+	}
+
+	return nil
+}
+
+type archiveWriter interface {
+	// WriteHeader adds an entry to the archive. If link is not empty, the
+	// entry is a symbolic link. Data written to the returned writer is the
+	// content of the entry.
+	WriteHeader(
+		name string,
+		fi fs.FileInfo,
+		link string,
+		mode fs.FileMode,
+	) (io.Writer, error)
+
+	Close() error
+}
+
+type tarWriter struct {
+	w  *tar.Writer
+	gz *gzip.Writer
+}
+
+func newTarWriter(w io.Writer, compress bool) *tarWriter {
+	tw := &tarWriter{}
+
+	if compress {
+		tw.gz = gzip.NewWriter(w)
+		w = tw.gz
+	}
+
+	tw.w = tar.NewWriter(w)
+
+	return tw
+}
+
+func (tw *tarWriter) WriteHeader(
+	name string,
+	fi fs.FileInfo,
+	link string,
+	mode fs.FileMode,
+) (io.Writer, error) {
+	h, err := tar.FileInfoHeader(fi, link)
+	if err != nil {
+		return nil, err //nolint:wrapcheck
+	}
+
+	h.Name = name
+	h.Mode = int64(mode.Perm())
+
+	if fi.IsDir() {
+		h.Name += "/"
+	}
+
+	if err := tw.w.WriteHeader(h); err != nil {
+		return nil, err //nolint:wrapcheck
+	}
+
+	return tw.w, nil
+}
+
+func (tw *tarWriter) Close() error {
+	if err := tw.w.Close(); err != nil {
+		return err //nolint:wrapcheck
+	}
+
+	if tw.gz != nil {
+		return tw.gz.Close() //nolint:wrapcheck
+	}
+
+	return nil
+}
+
+type zipWriter struct {
+	w *zip.Writer
+}
+
+func (zw *zipWriter) WriteHeader(
+	name string,
+	fi fs.FileInfo,
+	link string,
+	mode fs.FileMode,
+) (io.Writer, error) {
+	h, err := zip.FileInfoHeader(fi)
+	if err != nil {
+		return nil, err //nolint:wrapcheck
+	}
+
+	h.Name = name
+	h.Method = zip.Deflate
+	h.SetMode(fi.Mode()&^fs.ModePerm | mode.Perm())
+
+	if fi.IsDir() {
+		h.Name += "/"
+		h.Method = zip.Store
+	}
+
+	w, err := zw.w.CreateHeader(h)
+	if err != nil {
+		return nil, err //nolint:wrapcheck
+	}
+
+	// ZIP stores symbolic link targets as the entry content.
+	if link != "" {
+		if _, err := io.WriteString(w, link); err != nil {
+			return nil, err //nolint:wrapcheck
+		}
+	}
+
+	return w, nil
+}
+
+func (zw *zipWriter) Close() error {
+	return zw.w.Close() //nolint:wrapcheck
+}
+
+type extractor struct {
+	dst      string
+	opts     copyOptions
+	excluded []string
+}
+
+type archiveEntry struct {
+	name string
+	fi   fs.FileInfo
+	r    io.Reader
+
+	// link is the target of symbolic links and hard links.
+	link     string
+	hardlink bool
+}
+
+func (x *extractor) extractTar(r io.Reader) error {
+	tr := tar.NewReader(r)
+
+	for {
+		h, err := tr.Next()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+
+		if err != nil {
+			return NewCopyError(ErrCopyArchive, "", x.dst, err)
+		}
+
+		e := archiveEntry{name: h.Name, fi: h.FileInfo(), r: tr}
+
+		switch h.Typeflag {
+		case tar.TypeReg, tar.TypeDir:
+		case tar.TypeSymlink:
+			e.link = h.Linkname
+		case tar.TypeLink:
+			e.link, e.hardlink = h.Linkname, true
+		default:
+			return NewCopyError(ErrCopyArchive, h.Name, x.dst, errUnsupportedEntry)
+		}
+
+		if err := x.extract(e); err != nil {
+			return err
+		}
+	}
+}
+
+func (x *extractor) extractZip(r io.Reader) error {
+	ra, size, cleanup, err := readerAt(r)
+	if err != nil {
+		return NewCopyError(ErrCopyArchive, "", x.dst, err)
+	}
+
+	defer cleanup()
+
+	zr, err := zip.NewReader(ra, size)
+	if err != nil {
+		return NewCopyError(ErrCopyArchive, "", x.dst, err)
+	}
+
+	for _, f := range zr.File {
+		if err := x.extractZipFile(f); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (x *extractor) extractZipFile(f *zip.File) error {
+	rc, err := f.Open()
+	if err != nil {
+		return NewCopyError(ErrCopyOpenSrc, f.Name, x.dst, err)
+	}
+
+	defer rc.Close()
+
+	e := archiveEntry{name: f.Name, fi: f.FileInfo(), r: rc}
+
+	if e.fi.Mode()&fs.ModeSymlink != 0 {
+		target, err := io.ReadAll(io.LimitReader(rc, 4096))
+		if err != nil {
+			return NewCopyError(ErrCopySymlink, f.Name, x.dst, err)
+		}
+
+		e.link = string(target)
+	}
+
+	return x.extract(e)
+}
+
+func (x *extractor) extract(e archiveEntry) error {
+	name, err := safeName(e.name)
+	if err != nil {
+		return NewCopyError(ErrCopyUnsafePath, e.name, x.dst, err)
+	}
+
+	if name == "." || !x.include(name, e.fi) {
+		return nil
+	}
+
+	if e.link != "" && !e.hardlink && x.opts.symlinks == SymlinkSkip {
+		return nil
+	}
+
+	path := x.path(name)
+
+	if err := x.mkParents(name); err != nil {
+		return NewCopyError(ErrCopyMkdir, e.name, x.dst, err)
+	}
+
+	if err := x.write(path, name, e); err != nil {
+		return NewCopyError(ErrCopyWrite, e.name, x.dst, err)
+	}
+
+	if x.opts.times && e.link == "" {
+		mt := e.fi.ModTime()
+		if err := os.Chtimes(path, mt, mt); err != nil {
+			return NewCopyError(ErrCopyAttr, e.name, x.dst, err)
+		}
+	}
+
+	return nil
+}
+
+func (x *extractor) write(path, name string, e archiveEntry) error {
+	perm := x.opts.perm(e.fi.Mode().Perm())
+
+	if e.fi.IsDir() {
+		err := os.Mkdir(path, perm)
+		if errors.Is(err, fs.ErrExist) {
+			return x.checkDir(name)
+		}
+
+		return err //nolint:wrapcheck
+	}
+
+	// Directories are never replaced, links validated through them could
+	// point somewhere else otherwise.
+	if fi, err := os.Lstat(path); err == nil && fi.IsDir() {
+		return errors.New(name + " is a directory")
+	}
+
+	// Existing files are replaced, not written, so symbolic links are never
+	// followed.
+	if err := os.Remove(path); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return err //nolint:wrapcheck
+	}
+
+	switch {
+	case e.hardlink:
+		target, err := safeName(e.link)
+		if err != nil {
+			return err
+		}
+
+		if err := x.checkLink(".", target); err != nil {
+			return err
+		}
+
+		return os.Link(x.path(target), path) //nolint:wrapcheck
+	case e.link != "":
+		if err := safeLink(name, e.link); err != nil {
+			return err
+		}
+
+		if err := x.checkLink(filepath.Dir(name), e.link); err != nil {
+			return err
+		}
+
+		return os.Symlink(e.link, path) //nolint:wrapcheck
+	}
+
+	flags := os.O_CREATE | os.O_EXCL | os.O_WRONLY
+
+	f, err := os.OpenFile(path, flags, perm)
+	if err != nil {
+		return err //nolint:wrapcheck
+	}
+
+	defer f.Close()
+
+	if _, err := io.Copy(f, e.r); err != nil {
+		return err //nolint:wrapcheck
+	}
+
+	return f.Close() //nolint:wrapcheck
+}
+
+// include applies the filter from options to name, excluded directories are
+// tracked, so their content is excluded too.
+func (x *extractor) include(name string, fi fs.FileInfo) bool {
+	for _, dir := range x.excluded {
+		if strings.HasPrefix(name, dir+"/") {
+			return false
+		}
+	}
+
+	if x.opts.filter == nil || x.opts.filter(name, fi) {
+		return true
+	}
+
+	if fi.IsDir() {
+		x.excluded = append(x.excluded, name)
+	}
+
+	return false
+}
+
+// mkParents creates missing parent directories of name. Existing parents
+// must be directories, symbolic links are refused.
+func (x *extractor) mkParents(name string) error {
+	dir := path.Dir(name)
+	if dir == "." {
+		return nil
+	}
+
+	elems := strings.Split(dir, "/")
+
+	for i := range elems {
+		parent := path.Join(elems[:i+1]...)
+
+		err := os.Mkdir(x.path(parent), x.opts.perm(0o755))
+		if err == nil {
+			continue
+		}
+
+		if !errors.Is(err, fs.ErrExist) {
+			return err //nolint:wrapcheck
+		}
+
+		if err := x.checkDir(parent); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// checkLink refuses link targets that resolve outside the extraction
+// directory. target is resolved from dir against the file system, so symbolic
+// links from previous entries are followed.
+func (x *extractor) checkLink(dir, target string) error {
+	root, err := filepath.EvalSymlinks(x.dst)
+	if err != nil {
+		return err //nolint:wrapcheck
+	}
+
+	p, err := filepath.EvalSymlinks(x.path(dir))
+	if err != nil {
+		return err //nolint:wrapcheck
+	}
+
+	for _, elem := range strings.Split(filepath.ToSlash(target), "/") {
+		switch elem {
+		case "", ".":
+			continue
+		case "..":
+			p = filepath.Dir(p)
+		default:
+			p = filepath.Join(p, elem)
+
+			fi, err := os.Lstat(p)
+			if err == nil && fi.Mode()&fs.ModeSymlink != 0 {
+				if p, err = filepath.EvalSymlinks(p); err != nil {
+					return err //nolint:wrapcheck
+				}
+			}
+		}
+
+		if p != root && !strings.HasPrefix(p, root+string(filepath.Separator)) {
+			return errors.New("link target " + target + " is outside directory")
+		}
+	}
+
+	return nil
+}
+
+func (x *extractor) checkDir(name string) error {
+	fi, err := os.Lstat(x.path(name))
+	if err != nil {
+		return err //nolint:wrapcheck
+	}
+
+	if !fi.IsDir() {
+		return errors.New(name + " is not a directory")
+	}
+
+	return nil
+}
+
+// path returns the destination path of the entry name.
+func (x *extractor) path(name string) string {
+	return filepath.Join(x.dst, filepath.FromSlash(name))
+}
+
+// safeName cleans an entry name. Absolute paths and paths with parent
+// directory elements are refused.
+func safeName(name string) (string, error) {
+	name = strings.ReplaceAll(name, "\\", "/")
+
+	if path.IsAbs(name) || filepath.VolumeName(name) != "" {
+		return "", errors.New("absolute path " + name)
+	}
+
+	for _, elem := range strings.Split(name, "/") {
+		if elem == ".." {
+			return "", errors.New("parent directory element in " + name)
+		}
+	}
+
+	return path.Clean(name), nil
+}
+
+// safeLink refuses symbolic link targets that point outside the extraction
+// directory.
+func safeLink(name, target string) error {
+	if path.IsAbs(target) || filepath.IsAbs(target) {
+		return errors.New("absolute link target " + target)
+	}
+
+	p := path.Join(path.Dir(name), filepath.ToSlash(target))
+	if p == ".." || strings.HasPrefix(p, "../") {
+		return errors.New("link target " + target + " is outside directory")
+	}
+
+	return nil
+}
+
+// readerAt returns r as an io.ReaderAt. If r doesn't implement it, its content
+// is buffered into a temporary file, cleanup removes it.
+func readerAt(r io.Reader) (io.ReaderAt, int64, func(), error) {
+	nop := func() {}
+
+	switch v := r.(type) {
+	case interface {
+		io.ReaderAt
+		Size() int64
+	}:
+		return v, v.Size(), nop, nil
+	case *os.File:
+		fi, err := v.Stat()
+		if err != nil {
+			return nil, 0, nop, err //nolint:wrapcheck
+		}
+
+		return v, fi.Size(), nop, nil
+	}
+
+	f, err := os.CreateTemp("", "ntgo-os-unpack-*.zip")
+	if err != nil {
+		return nil, 0, nop, err //nolint:wrapcheck
+	}
+
+	cleanup := func() {
+		f.Close()
+		os.Remove(f.Name())
+	}
+
+	size, err := io.Copy(f, r)
+	if err != nil {
+		cleanup()
+		return nil, 0, nop, err //nolint:wrapcheck
+	}
+
+	return f, size, cleanup, nil
+}
@@ -0,0 +1,212 @@
+// Copyright 2026 Miguel Angel Rivera Notararigo. All rights reserved.
+// This source code was released under the MIT license.
+
+package os_test
+
+import (
+	"archive/tar"
+	"bytes"
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"testing"
+
+	ntos "go.ntrrg.dev/ntgo/os"
+)
+
+func TestPack(t *testing.T) {
+	t.Parallel()
+
+	dir, err := os.MkdirTemp("", "ntgo-os-pack")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	defer os.RemoveAll(dir)
+
+	src := filepath.Join(dir, "source")
+
+	writeTree(t, src, map[string]string{
+		"file.txt":         "hello, world!",
+		"sub/nested.txt":   "nested",
+		"sub/ignored.tmp":  "ignored",
+		"ignored/file.txt": "ignored",
+	})
+
+	err = os.Symlink("sub/nested.txt", filepath.Join(src, "link"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	formats := []struct {
+		label  string
+		format ntos.ArchiveFormat
+	}{
+		{label: "Tar", format: ntos.Tar},
+		{label: "TarGzip", format: ntos.TarGzip},
+		{label: "Zip", format: ntos.Zip},
+	}
+
+	opts := []ntos.CopyOption{
+		ntos.WithFilter(ntos.Exclude("*.tmp", "ignored")),
+		ntos.WithSymlinks(ntos.SymlinkPreserve),
+	}
+
+	for _, f := range formats {
+		var buf bytes.Buffer
+
+		if err := ntos.Pack(&buf, src, f.format, opts...); err != nil {
+			t.Fatalf("[%s] Pack failed: %v", f.label, err)
+		}
+
+		dst := filepath.Join(dir, f.label)
+
+		// bytes.Buffer doesn't implement io.ReaderAt, so zip archives are
+		// buffered.
+		if err := ntos.Unpack(dst, &buf, f.format, opts...); err != nil {
+			t.Fatalf("[%s] Unpack failed: %v", f.label, err)
+		}
+
+		for _, name := range []string{"file.txt", "sub/nested.txt"} {
+			err := compareFiles(filepath.Join(dst, name), filepath.Join(src, name))
+			if err != nil {
+				t.Errorf("[%s] %v", f.label, err)
+			}
+		}
+
+		for _, name := range []string{"sub/ignored.tmp", "ignored"} {
+			_, err := os.Lstat(filepath.Join(dst, name))
+			if !errors.Is(err, fs.ErrNotExist) {
+				t.Errorf("[%s] excluded file %s was extracted", f.label, name)
+			}
+		}
+
+		target, err := os.Readlink(filepath.Join(dst, "link"))
+		if err != nil {
+			t.Errorf("[%s] symbolic link was not extracted: %v", f.label, err)
+		} else if target != "sub/nested.txt" {
+			t.Errorf("[%s] invalid link target: %q", f.label, target)
+		}
+	}
+}
+
+func TestUnpack_unsafe(t *testing.T) {
+	t.Parallel()
+
+	dir, err := os.MkdirTemp("", "ntgo-os-unpack_unsafe")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	defer os.RemoveAll(dir)
+
+	cases := []struct {
+		label   string
+		entries []tar.Header
+	}{
+		{
+			label:   "Parent directory",
+			entries: []tar.Header{{Name: "../evil.txt"}},
+		},
+		{
+			label:   "Nested parent directory",
+			entries: []tar.Header{{Name: "sub/../../evil.txt"}},
+		},
+		{
+			label:   "Absolute path",
+			entries: []tar.Header{{Name: "/tmp/evil.txt"}},
+		},
+		{
+			label: "Symbolic link escape",
+			entries: []tar.Header{
+				{Name: "link", Typeflag: tar.TypeSymlink, Linkname: "../.."},
+			},
+		},
+		{
+			label: "Absolute symbolic link",
+			entries: []tar.Header{
+				{Name: "link", Typeflag: tar.TypeSymlink, Linkname: "/etc"},
+			},
+		},
+		{
+			label: "Write through symbolic link",
+			entries: []tar.Header{
+				{Name: "sub/", Typeflag: tar.TypeDir, Mode: 0o755},
+				{Name: "link", Typeflag: tar.TypeSymlink, Linkname: "sub"},
+				{Name: "link/evil.txt"},
+			},
+		},
+		{
+			label: "Symbolic link escape through previous link",
+			entries: []tar.Header{
+				{Name: "a/", Typeflag: tar.TypeDir, Mode: 0o755},
+				{Name: "a/b", Typeflag: tar.TypeSymlink, Linkname: ".."},
+				{Name: "l", Typeflag: tar.TypeSymlink, Linkname: "a/b/.."},
+				{Name: "h", Typeflag: tar.TypeLink, Linkname: "l/outside.txt"},
+			},
+		},
+		{
+			label: "Directory replaced by symbolic link",
+			entries: []tar.Header{
+				{Name: "a/", Typeflag: tar.TypeDir, Mode: 0o755},
+				{Name: "l", Typeflag: tar.TypeSymlink, Linkname: "a/.."},
+				{Name: "a", Typeflag: tar.TypeSymlink, Linkname: "."},
+				{Name: "h", Typeflag: tar.TypeLink, Linkname: "l/outside.txt"},
+			},
+		},
+	}
+
+	outside := filepath.Join(dir, "case", "outside.txt")
+
+	if err := os.MkdirAll(filepath.Dir(outside), 0o755); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := os.WriteFile(outside, []byte("safe"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	for i, c := range cases {
+		var buf bytes.Buffer
+
+		tw := tar.NewWriter(&buf)
+
+		for _, h := range c.entries {
+			h := h
+
+			if h.Typeflag == 0 {
+				h.Typeflag, h.Mode, h.Size = tar.TypeReg, 0o644, 4
+			}
+
+			if err := tw.WriteHeader(&h); err != nil {
+				t.Fatal(err)
+			}
+
+			if h.Size > 0 {
+				if _, err := tw.Write([]byte("evil")); err != nil {
+					t.Fatal(err)
+				}
+			}
+		}
+
+		if err := tw.Close(); err != nil {
+			t.Fatal(err)
+		}
+
+		dst := filepath.Join(dir, "case", string(rune('a'+i)))
+
+		var cerr *ntos.CopyError
+		if err := ntos.Unpack(dst, &buf, ntos.Tar); !errors.As(err, &cerr) {
+			t.Errorf("[%s] Unpack succeeded with unsafe entry: %v", c.label, err)
+		}
+	}
+
+	if _, err := os.Stat(filepath.Join(dir, "evil.txt")); err == nil {
+		t.Error("file was extracted outside the destination directory")
+	}
+
+	if data, err := os.ReadFile(outside); err != nil || string(data) != "safe" {
+		t.Errorf("file outside the destination directory was modified: %v", err)
+	}
+}
@@ -0,0 +1,206 @@
+// Copyright 2026 Miguel Angel Rivera Notararigo. All rights reserved.
+// This source code was released under the MIT license.
+
+package os
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/hex"
+	"errors"
+	"hash"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	nterrors "go.ntrrg.dev/ntgo/errors"
+)
+
+// WithHash sets the hash function used for computing digests. Default is
+// SHA-256.
+func WithHash(fn func() hash.Hash) CopyOption {
+	return func(o *copyOptions) {
+		o.hash = fn
+	}
+}
+
+// WithManifest records the digest of every copied file into m, which must not
+// be nil. Keys are slash-separated paths relative to the copied directory, or
+// the destination file name for single file copies.
+func WithManifest(m Manifest) CopyOption {
+	return func(o *copyOptions) {
+		o.manifest = m
+	}
+}
+
+// Manifest maps slash-separated file paths to their digests.
+type Manifest map[string][]byte
+
+// ReadManifest reads a manifest in the format written by Manifest.WriteTo.
+func ReadManifest(r io.Reader) (Manifest, error) {
+	m := Manifest{}
+	s := bufio.NewScanner(r)
+
+	for i := 1; s.Scan(); i++ {
+		line := s.Text()
+		if line == "" {
+			continue
+		}
+
+		digest, path, ok := strings.Cut(line, "  ")
+		if !ok || path == "" {
+			err := errors.New("line " + strconv.Itoa(i) + ": missing path")
+			return nil, ErrInvalidManifest.Wrap(err)
+		}
+
+		sum, err := hex.DecodeString(digest)
+		if err != nil {
+			return nil, ErrInvalidManifest.Wrap(err)
+		}
+
+		m[path] = sum
+	}
+
+	if err := s.Err(); err != nil {
+		return nil, ErrInvalidManifest.Wrap(err)
+	}
+
+	return m, nil
+}
+
+// Paths returns the paths from m in lexical order.
+func (m Manifest) Paths() []string {
+	paths := make([]string, 0, len(m))
+
+	for p := range m {
+		paths = append(paths, p)
+	}
+
+	sort.Strings(paths)
+
+	return paths
+}
+
+// WriteTo writes m into w with the format used by tools like sha256sum, one
+// "<hex digest>  <path>" line per file, sorted by path.
+func (m Manifest) WriteTo(w io.Writer) (int64, error) {
+	var n int64
+
+	for _, p := range m.Paths() {
+		written, err := io.WriteString(w, hex.EncodeToString(m[p])+"  "+p+"\n")
+		n += int64(written)
+
+		if err != nil {
+			return n, err //nolint:wrapcheck
+		}
+	}
+
+	return n, nil
+}
+
+// VerifyManifest checks that files from dir have the digests recorded in m.
+// The hash function may be set with WithHash, other copy options are ignored.
+//
+// Every missing or mismatching file is reported as a CopyError, with the
+// manifest path as Src and the file path as Dst. Errors are grouped with the
+// errors package, mismatches wrap ErrCopyChecksum.
+func VerifyManifest(dir string, m Manifest, opts ...CopyOption) error {
+	o := newCopyOptions(opts)
+
+	var errs []error
+
+	for _, p := range m.Paths() {
+		path := filepath.Join(dir, filepath.FromSlash(p))
+
+		sum, err := hashFile(path, o.hash)
+		if err != nil {
+			errs = append(errs, NewCopyError(ErrCopyOpenDst, p, path, err))
+			continue
+		}
+
+		if !bytes.Equal(sum, m[p]) {
+			err := NewCopyError(ErrCopyChecksum, p, path, nil)
+			errs = append(errs, err)
+		}
+	}
+
+	if len(errs) > 0 {
+		return nterrors.Group(errs...)
+	}
+
+	return nil
+}
+
+// copyHashed copies data in user space, computing its digest while it is
+// copied.
+func copyHashed(
+	dst, src string,
+	to io.Writer,
+	from io.Reader,
+	o copyOptions,
+	name string,
+) error {
+	h := o.hash()
+
+	if _, err := io.Copy(io.MultiWriter(to, h), from); err != nil {
+		return NewCopyError(ErrCopyWrite, src, dst, err)
+	}
+
+	return checkCopied(dst, src, h.Sum(nil), o, name)
+}
+
+// hashCopied computes the digest of src after being copied to dst by other
+// means than copyHashed (e.g. reflinks).
+func hashCopied(dst, src string, o copyOptions, name string) error {
+	sum, err := hashFile(src, o.hash)
+	if err != nil {
+		return NewCopyError(ErrCopyOpenSrc, src, dst, err)
+	}
+
+	return checkCopied(dst, src, sum, o, name)
+}
+
+// checkCopied compares sum with the digest of dst if verification is enabled,
+// and adds it to the manifest.
+func checkCopied(
+	dst, src string,
+	sum []byte,
+	o copyOptions,
+	name string,
+) error {
+	if o.verify {
+		dsum, err := hashFile(dst, o.hash)
+		if err != nil {
+			return NewCopyError(ErrCopyOpenDst, src, dst, err)
+		}
+
+		if !bytes.Equal(sum, dsum) {
+			return NewCopyError(ErrCopyChecksum, src, dst, nil)
+		}
+	}
+
+	if o.manifest != nil {
+		o.manifest[name] = sum
+	}
+
+	return nil
+}
+
+func hashFile(path string, fn func() hash.Hash) ([]byte, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err //nolint:wrapcheck
+	}
+
+	defer f.Close()
+
+	h := fn()
+	if _, err := io.Copy(h, f); err != nil {
+		return nil, err //nolint:wrapcheck
+	}
+
+	return h.Sum(nil), nil
+}
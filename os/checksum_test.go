@@ -0,0 +1,151 @@
+// Copyright 2026 Miguel Angel Rivera Notararigo. All rights reserved.
+// This source code was released under the MIT license.
+
+package os_test
+
+import (
+	"bytes"
+	"crypto/sha1" //nolint:gosec
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	nterrors "go.ntrrg.dev/ntgo/errors"
+	ntos "go.ntrrg.dev/ntgo/os"
+)
+
+func TestCopyDir_manifest(t *testing.T) {
+	t.Parallel()
+
+	dir, err := os.MkdirTemp("", "ntgo-os-copy-dir_manifest")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	defer os.RemoveAll(dir)
+
+	src := filepath.Join(dir, "source")
+	dst := filepath.Join(dir, "destination")
+
+	files := map[string]string{
+		"file.txt":     "hello, world!",
+		"sub/file.txt": "nested",
+	}
+
+	writeTree(t, src, files)
+
+	m := ntos.Manifest{}
+
+	opts := []ntos.CopyOption{ntos.WithManifest(m), ntos.WithVerify()}
+	if err := ntos.CopyDir(dst, src, 0o700, opts...); err != nil {
+		t.Fatalf("CopyDir failed to copy a valid directory: %v", err)
+	}
+
+	if len(m) != len(files) {
+		t.Fatalf("invalid manifest. got: %d entries, want: %d", len(m), len(files))
+	}
+
+	for name, data := range files {
+		want := sha256.Sum256([]byte(data))
+		if got := m[name]; !bytes.Equal(got, want[:]) {
+			t.Errorf("invalid digest for %s. got: %x, want: %x", name, got, want)
+		}
+	}
+
+	if err := ntos.VerifyManifest(dst, m); err != nil {
+		t.Errorf("valid copy has errors: %v", err)
+	}
+
+	var buf bytes.Buffer
+
+	if _, err := m.WriteTo(&buf); err != nil {
+		t.Fatal(err)
+	}
+
+	m2, err := ntos.ReadManifest(&buf)
+	if err != nil {
+		t.Fatalf("cannot read manifest: %v", err)
+	}
+
+	for name, sum := range m {
+		if !bytes.Equal(m2[name], sum) {
+			t.Errorf("invalid parsed digest for %s. got: %x", name, m2[name])
+		}
+	}
+
+	writeTree(t, dst, map[string]string{"sub/file.txt": "modified"})
+
+	if err := os.Remove(filepath.Join(dst, "file.txt")); err != nil {
+		t.Fatal(err)
+	}
+
+	errs := nterrors.Split(ntos.VerifyManifest(dst, m2))
+	if len(errs) != 2 {
+		t.Fatalf("invalid verification errors. got: %v", errs)
+	}
+
+	var cerr *ntos.CopyError
+	if !errors.As(errs[0], &cerr) || !errors.Is(errs[0], os.ErrNotExist) {
+		t.Errorf("missing file not reported. got: %v", errs[0])
+	}
+
+	if !errors.As(errs[1], &cerr) || !errors.Is(errs[1], ntos.ErrCopyChecksum) {
+		t.Errorf("modified file not reported. got: %v", errs[1])
+	} else if cerr.Src != "sub/file.txt" {
+		t.Errorf("invalid manifest path. got: %q", cerr.Src)
+	}
+}
+
+func TestCopyFile_hash(t *testing.T) {
+	t.Parallel()
+
+	dir, err := os.MkdirTemp("", "ntgo-os-copy-file_hash")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	defer os.RemoveAll(dir)
+
+	src := filepath.Join(dir, "source.txt")
+	dst := filepath.Join(dir, "destination.txt")
+
+	if err := os.WriteFile(src, []byte("hello, world!"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	m := ntos.Manifest{}
+
+	err = ntos.CopyFile(
+		dst, src, 0o600,
+		ntos.WithHash(sha1.New),
+		ntos.WithManifest(m),
+	)
+
+	if err != nil {
+		t.Fatalf("CopyFile failed to copy a valid file: %v", err)
+	}
+
+	want := "1f09d30c707d53f3d16c530dd73d70a6ce7596a9"
+	if got := hex.EncodeToString(m["destination.txt"]); got != want {
+		t.Errorf("invalid digest. got: %s, want: %s", got, want)
+	}
+}
+
+func TestReadManifest_invalid(t *testing.T) {
+	t.Parallel()
+
+	cases := []string{
+		"not-hex  file.txt\n",
+		"abcdef\n",
+	}
+
+	for _, c := range cases {
+		_, err := ntos.ReadManifest(bytes.NewBufferString(c))
+		if !errors.Is(err, ntos.ErrInvalidManifest) {
+			t.Errorf("invalid manifest %q was parsed: %v", c, err)
+		}
+	}
+}
@@ -4,104 +4,402 @@
 package os
 
 import (
+	"crypto/sha256"
 	"errors"
+	"hash"
 	"io"
+	"io/fs"
 	"os"
 	"path/filepath"
 	"strings"
+
+	nterrors "go.ntrrg.dev/ntgo/errors"
 )
 
+var errSymlinkLoop = errors.New("symbolic link loop")
+
 // Copy copies src content into dst. If dst doesn't exists, it will be created.
 // src mode will override dst mode. Only file-file or directory-directory
 // operations should be performed.
-func Copy(dst, src string) error {
+func Copy(dst, src string, opts ...CopyOption) error {
 	sfi, err := os.Stat(src)
 	if err != nil {
-		return NewCopyError(src, dst, "cannot stat source file", err)
+		return NewCopyError(ErrCopyStat, src, dst, err)
 	}
 
 	if sfi.IsDir() {
-		return CopyDir(dst, src, sfi.Mode())
+		return CopyDir(dst, src, sfi.Mode(), opts...)
 	}
 
-	return CopyFile(dst, src, sfi.Mode())
+	return CopyFile(dst, src, sfi.Mode(), opts...)
 }
 
 // CopyDir copies src content recursively into dst, if dst doesn't exists it
 // will be created. mode will be the new dst mode, its content will preserve
 // the origin mode.
-func CopyDir(dst, src string, mode os.FileMode) error {
+func CopyDir(dst, src string, mode os.FileMode, opts ...CopyOption) error {
+	return copyDir(dst, src, newCopyOptions(opts), "")
+}
+
+// copyDir copies src content recursively into dst. prefix is prepended to
+// relative paths given to filters and manifests.
+func copyDir(dst, src string, o copyOptions, prefix string) error {
 	if err := isInside(dst, src); err != nil {
 		return err
 	}
 
 	fn := func(srcpath string, fi os.FileInfo, err error) error {
 		if err != nil {
-			return NewCopyError(src, dst, "cannot stat "+srcpath, err)
+			return NewCopyError(ErrCopyStat, srcpath, dst, err)
+		}
+
+		name := prefix + relPath(src, srcpath)
+
+		if srcpath != src && !o.include(name, fi) {
+			if fi.IsDir() {
+				return filepath.SkipDir
+			}
+
+			return nil
 		}
 
 		dest := filepath.Clean(strings.Replace(srcpath, src, dst, 1))
 
-		if fi.IsDir() {
-			err := os.Mkdir(dest, fi.Mode())
+		switch {
+		case fi.IsDir():
+			err := os.Mkdir(dest, o.perm(fi.Mode()))
 			if err != nil && !errors.Is(err, os.ErrExist) {
-				return NewCopyError(src, dst, "cannot create directory "+dest, err)
+				return NewCopyError(ErrCopyMkdir, srcpath, dest, err)
 			}
 
 			return nil
+		case fi.Mode()&os.ModeSymlink != 0:
+			return copyDirSymlink(dest, srcpath, o, name)
 		}
 
-		return CopyFile(dest, srcpath, fi.Mode())
+		return copyFile(dest, srcpath, fi.Mode(), o, name)
 	}
 
 	return filepath.Walk(src, fn) //nolint:wrapcheck
 }
 
+// CopyFS copies fsys content recursively into dst, if dst doesn't exists it
+// will be created. Files preserve their mode from fsys, but directories are
+// always writable by their owner, so their content can be modified. Symbolic
+// links are followed, unless SymlinkSkip is used. Reflinks and modification
+// times are not supported, so ReflinkAlways makes copies fail.
+func CopyFS(dst string, fsys fs.FS, opts ...CopyOption) error {
+	return copyFS(dst, fsys, newCopyOptions(opts), "")
+}
+
+// copyFS copies fsys content recursively into dst. prefix is prepended to
+// relative paths given to filters and manifests.
+func copyFS(dst string, fsys fs.FS, o copyOptions, prefix string) error {
+	fn := func(path string, d fs.DirEntry, err error) error {
+		dest := filepath.Join(dst, filepath.FromSlash(path))
+
+		if err != nil {
+			return NewCopyError(ErrCopyStat, path, dest, err)
+		}
+
+		fi, err := d.Info()
+		if err != nil {
+			return NewCopyError(ErrCopyStat, path, dest, err)
+		}
+
+		name := prefix + path
+
+		if path != "." && !o.include(name, fi) {
+			if fi.IsDir() {
+				return fs.SkipDir
+			}
+
+			return nil
+		}
+
+		if fi.Mode()&fs.ModeSymlink != 0 {
+			if o.symlinks == SymlinkSkip {
+				return nil
+			}
+
+			return copyFSSymlink(dest, fsys, path, o, name)
+		}
+
+		if fi.IsDir() {
+			err := os.Mkdir(dest, o.perm(fi.Mode().Perm()|0o700))
+			if err != nil && !errors.Is(err, os.ErrExist) {
+				return NewCopyError(ErrCopyMkdir, path, dest, err)
+			}
+
+			return nil
+		}
+
+		return copyFSFile(dest, fsys, path, fi.Mode(), o, name)
+	}
+
+	return fs.WalkDir(fsys, ".", fn) //nolint:wrapcheck
+}
+
+func copyFSFile(
+	dst string,
+	fsys fs.FS,
+	path string,
+	mode os.FileMode,
+	o copyOptions,
+	name string,
+) error {
+	if o.reflink == ReflinkAlways {
+		return NewCopyError(ErrCopyClone, path, dst, ErrNotSupported)
+	}
+
+	from, err := fsys.Open(path)
+	if err != nil {
+		return NewCopyError(ErrCopyOpenSrc, path, dst, err)
+	}
+
+	defer from.Close()
+
+	flags := os.O_CREATE | os.O_WRONLY | os.O_TRUNC
+
+	to, err := os.OpenFile(dst, flags, o.perm(mode))
+	if err != nil {
+		return NewCopyError(ErrCopyOpenDst, path, dst, err)
+	}
+
+	defer to.Close()
+
+	if o.verify || o.manifest != nil {
+		return copyHashed(dst, path, to, from, o, name)
+	}
+
+	if _, err := io.Copy(to, from); err != nil {
+		return NewCopyError(ErrCopyWrite, path, dst, err)
+	}
+
+	return nil
+}
+
+// copyFSSymlink copies the target of the symbolic link at path.
+func copyFSSymlink(
+	dst string,
+	fsys fs.FS,
+	path string,
+	o copyOptions,
+	name string,
+) error {
+	fi, err := fs.Stat(fsys, path)
+	if err != nil {
+		return NewCopyError(ErrCopySymlink, path, dst, err)
+	}
+
+	if !fi.IsDir() {
+		return copyFSFile(dst, fsys, path, fi.Mode(), o, name)
+	}
+
+	sub, err := fs.Sub(fsys, path)
+	if err != nil {
+		return NewCopyError(ErrCopySymlink, path, dst, err)
+	}
+
+	return copyFS(dst, sub, o, name+"/")
+}
+
 // CopyFile copies src content into dst, if dst exists it will be truncated.
 // mode will be the new dst mode.
-func CopyFile(dst, src string, mode os.FileMode) error {
+//
+// When supported by the platform, data is cloned (see ReflinkMode) or copied
+// in kernel space, holes from sparse files are preserved as well. If digests
+// are needed (see WithManifest and WithVerify), data is copied in user space,
+// unless ReflinkAlways is used, in which case files are hashed after being
+// cloned.
+func CopyFile(dst, src string, mode os.FileMode, opts ...CopyOption) error {
+	return copyFile(dst, src, mode, newCopyOptions(opts), filepath.Base(dst))
+}
+
+// copyFile copies src content into dst. name is used as manifest key.
+func copyFile(
+	dst, src string,
+	mode os.FileMode,
+	o copyOptions,
+	name string,
+) error {
 	from, err := os.Open(src)
 	if err != nil {
-		return NewCopyError(src, dst, "cannot open source file", err)
+		return NewCopyError(ErrCopyOpenSrc, src, dst, err)
 	}
 
 	defer from.Close()
 
-	to, err := os.OpenFile(dst, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, mode)
+	flags := os.O_CREATE | os.O_WRONLY | os.O_TRUNC
+
+	to, err := os.OpenFile(dst, flags, o.perm(mode))
 	if err != nil {
-		return NewCopyError(src, dst, "cannot open destination file", err)
+		return NewCopyError(ErrCopyOpenDst, src, dst, err)
 	}
 
 	defer to.Close()
 
-	if _, err = io.Copy(to, from); err != nil {
-		return NewCopyError(src, dst, "cannot copy data", err)
+	hashed := o.verify || o.manifest != nil
+
+	// Required clones are hashed after being made.
+	if hashed && o.reflink != ReflinkAlways {
+		if err := copyHashed(dst, src, to, from, o, name); err != nil {
+			return err
+		}
+	} else if err := cloneOrCopy(to, from, o.reflink); err != nil {
+		if o.reflink == ReflinkAlways {
+			return NewCopyError(ErrCopyClone, src, dst, err)
+		}
+
+		return NewCopyError(ErrCopyWrite, src, dst, err)
+	} else if hashed {
+		if err := hashCopied(dst, src, o, name); err != nil {
+			return err
+		}
+	}
+
+	if o.times {
+		if err := copyModTime(to, from); err != nil {
+			return NewCopyError(ErrCopyAttr, src, dst, err)
+		}
 	}
 
 	return nil
 }
 
-// CopyError records an error during a copy operation. If Err is nil, it means
-// the error doesn't wrap any error from another package.
+// CopyOption customizes copy operations.
+type CopyOption func(*copyOptions)
+
+// WithReflink sets the reflink policy for copy operations. Default is
+// ReflinkAuto.
+func WithReflink(m ReflinkMode) CopyOption {
+	return func(o *copyOptions) {
+		o.reflink = m
+	}
+}
+
+// ReflinkMode controls the use of reflinks (copy-on-write clones) when copying
+// files. Reflinks share data blocks between source and destination until one
+// of them is modified, which makes copies instant and space efficient.
+type ReflinkMode int
+
+const (
+	// ReflinkAuto clones files if supported by the file system, falling back
+	// to regular copies otherwise.
+	ReflinkAuto ReflinkMode = iota
+
+	// ReflinkAlways requires file cloning, copies fail if it is not supported.
+	ReflinkAlways
+
+	// ReflinkNever disables file cloning.
+	ReflinkNever
+)
+
+// WithFilter sets a filter for selecting the files to copy from directories.
+// Excluded directories are skipped with all their content.
+func WithFilter(f Filter) CopyOption {
+	return func(o *copyOptions) {
+		o.filter = f
+	}
+}
+
+// WithSymlinks sets how symbolic links from directories are copied. Default is
+// SymlinkFollow.
+func WithSymlinks(m SymlinkMode) CopyOption {
+	return func(o *copyOptions) {
+		o.symlinks = m
+	}
+}
+
+// WithUmask clears the permission bits set in mask from the mode of created
+// files and directories.
+func WithUmask(mask os.FileMode) CopyOption {
+	return func(o *copyOptions) {
+		o.umask = mask & os.ModePerm
+	}
+}
+
+// WithTimes preserves source modification times in copied files.
+func WithTimes() CopyOption {
+	return func(o *copyOptions) {
+		o.times = true
+	}
+}
+
+// WithVerify compares the digest of copied files with the digest of the data
+// read from their source. The hash function may be set with WithHash.
+func WithVerify() CopyOption {
+	return func(o *copyOptions) {
+		o.verify = true
+	}
+}
+
+// SymlinkMode controls how symbolic links are copied.
+type SymlinkMode int
+
+const (
+	// SymlinkFollow copies the content of symbolic link targets.
+	SymlinkFollow SymlinkMode = iota
+
+	// SymlinkPreserve creates symbolic links with the same targets.
+	SymlinkPreserve
+
+	// SymlinkSkip ignores symbolic links.
+	SymlinkSkip
+)
+
+type copyOptions struct {
+	filter   Filter
+	hash     func() hash.Hash
+	manifest Manifest
+	reflink  ReflinkMode
+	symlinks SymlinkMode
+	times    bool
+	umask    os.FileMode
+	verify   bool
+
+	// walking holds the real directories where followed symbolic links are.
+	walking []string
+}
+
+// include reports if the file with the given relative path should be copied.
+func (o copyOptions) include(name string, fi os.FileInfo) bool {
+	return o.filter == nil || o.filter(name, fi)
+}
+
+func (o copyOptions) perm(mode os.FileMode) os.FileMode {
+	return mode &^ o.umask
+}
+
+func newCopyOptions(opts []CopyOption) copyOptions {
+	o := copyOptions{hash: sha256.New}
+
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	return o
+}
+
+// CopyError records the files involved in a failed copy operation. Copy
+// errors are returned wrapped by an error from the ErrCopy group, so they can
+// be identified by their code with errors.Is, Of and Parse, while errors.As
+// gives access to Src and Dst. If Err is nil, it means the error doesn't wrap
+// any error from another package.
 type CopyError struct {
 	Src, Dst string
-	Reason   string
 	Err      error
 }
 
-// NewCopyError creates a CopyError.
-func NewCopyError(src, dst, msg string, err error) error {
-	return &CopyError{
-		Src:    src,
-		Dst:    dst,
-		Reason: msg,
-		Err:    err,
-	}
+// NewCopyError creates an error from code, which should be part of the ErrCopy
+// group, that wraps a CopyError.
+func NewCopyError(code *nterrors.Error, src, dst string, err error) error {
+	return code.Wrap(&CopyError{Src: src, Dst: dst, Err: err})
 }
 
 // Error implements the error interface.
 func (e *CopyError) Error() string {
-	err := "cannot copy " + e.Src + " to " + e.Dst + ", " + e.Reason
+	err := e.Src + " -> " + e.Dst
 
 	if e.Err != nil {
 		err += ": " + e.Err.Error()
@@ -115,15 +413,107 @@ func (e *CopyError) Unwrap() error {
 	return e.Err
 }
 
+func copyDirSymlink(dst, src string, o copyOptions, name string) error {
+	switch o.symlinks {
+	case SymlinkSkip:
+		return nil
+	case SymlinkPreserve:
+		if err := copySymlink(dst, src); err != nil {
+			return NewCopyError(ErrCopySymlink, src, dst, err)
+		}
+
+		return nil
+	}
+
+	target, fi, o, err := followSymlink(src, o)
+	if err != nil {
+		return NewCopyError(ErrCopySymlink, src, dst, err)
+	}
+
+	if fi.IsDir() {
+		return copyDir(dst, target, o, name+"/")
+	}
+
+	return copyFile(dst, target, fi.Mode(), o, name)
+}
+
+// followSymlink resolves the symbolic link at path. Links to directories that
+// are being walked already are refused, since they would be walked endlessly.
+func followSymlink(
+	path string,
+	o copyOptions,
+) (string, os.FileInfo, copyOptions, error) {
+	target, err := filepath.EvalSymlinks(path)
+	if err != nil {
+		return "", nil, o, err //nolint:wrapcheck
+	}
+
+	fi, err := os.Stat(target)
+	if err != nil || !fi.IsDir() {
+		return target, fi, o, err //nolint:wrapcheck
+	}
+
+	// Every walk is stopped at the directory holding the link it followed.
+	dir, err := filepath.EvalSymlinks(filepath.Dir(path))
+	if err != nil {
+		return "", nil, o, err //nolint:wrapcheck
+	}
+
+	n := len(o.walking)
+	o.walking = append(o.walking[:n:n], dir)
+
+	prefix := strings.TrimSuffix(target, string(filepath.Separator)) +
+		string(filepath.Separator)
+
+	for _, p := range o.walking {
+		if p == target || strings.HasPrefix(p, prefix) {
+			return "", nil, o, errSymlinkLoop
+		}
+	}
+
+	return target, fi, o, nil
+}
+
+func cloneOrCopy(to, from *os.File, m ReflinkMode) error {
+	if m != ReflinkNever {
+		err := reflink(to, from)
+		if err == nil || m == ReflinkAlways {
+			return err
+		}
+	}
+
+	return copyData(to, from)
+}
+
+func copyModTime(to, from *os.File) error {
+	fi, err := from.Stat()
+	if err != nil {
+		return err //nolint:wrapcheck
+	}
+
+	return os.Chtimes(to.Name(), fi.ModTime(), fi.ModTime()) //nolint:wrapcheck
+}
+
+// relPath returns the slash-separated path of path relative to root. If
+// path is root, an empty string is returned.
+func relPath(root, path string) string {
+	rel, err := filepath.Rel(root, path)
+	if err != nil || rel == "." {
+		return ""
+	}
+
+	return filepath.ToSlash(rel)
+}
+
 func isInside(dst, src string) error {
 	srcabs, err := filepath.Abs(src)
 	if err != nil {
-		return NewCopyError(src, dst, "cannot get source absolute path", err)
+		return NewCopyError(ErrCopyPath, src, dst, err)
 	}
 
 	dstabs, err := filepath.Abs(dst)
 	if err != nil {
-		return NewCopyError(src, dst, "cannot get destination absolute path", err)
+		return NewCopyError(ErrCopyPath, src, dst, err)
 	}
 
 	if srcabs[len(srcabs)-1] != filepath.Separator {
@@ -131,7 +521,7 @@ func isInside(dst, src string) error {
 	}
 
 	if strings.HasPrefix(dstabs, srcabs) {
-		return NewCopyError(src, dst, "cannot copy a directory into itself", nil)
+		return NewCopyError(ErrCopySelf, src, dst, nil)
 	}
 
 	return nil
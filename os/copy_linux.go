@@ -0,0 +1,246 @@
+// Copyright 2026 Miguel Angel Rivera Notararigo. All rights reserved.
+// This source code was released under the MIT license.
+
+package os
+
+import (
+	"errors"
+	"io"
+	"os"
+	"syscall"
+	"unsafe"
+)
+
+const (
+	seekData = 3
+	seekHole = 4
+
+	// Maximum amount of bytes per copy_file_range/sendfile call, this is the
+	// same limit used by the Linux kernel (MAX_RW_COUNT).
+	maxCopyChunk = 0x7ffff000
+)
+
+// reflink clones from data into to using the FICLONE ioctl.
+func reflink(to, from *os.File) error {
+	_, _, errno := syscall.Syscall(
+		syscall.SYS_IOCTL,
+		to.Fd(),
+		sysFICLONE,
+		from.Fd(),
+	)
+
+	if errno != 0 {
+		return os.NewSyscallError("ioctl FICLONE", errno)
+	}
+
+	return nil
+}
+
+// copyData copies from data into to. Only data segments are copied, so holes
+// from sparse files are kept in the destination file.
+func copyData(to, from *os.File) error {
+	c := &fileCopier{to: to, from: from}
+
+	return c.copy()
+}
+
+type copyMethod int
+
+const (
+	copyFileRange copyMethod = iota
+	copySendfile
+	copyUserspace
+)
+
+// fileCopier copies data segments between files, falling back to the next
+// method when the current one is not supported.
+type fileCopier struct {
+	to, from *os.File
+	method   copyMethod
+	noHoles  bool
+}
+
+func (c *fileCopier) copy() error {
+	fi, err := c.from.Stat()
+	if err != nil {
+		return err //nolint:wrapcheck
+	}
+
+	size := fi.Size()
+
+	for off := int64(0); off < size; {
+		data, hole, err := c.nextSegment(off, size)
+		if err != nil {
+			return err
+		}
+
+		if data >= size {
+			break
+		}
+
+		if err := c.copyRange(data, hole-data); err != nil {
+			return err
+		}
+
+		off = hole
+	}
+
+	// Trailing holes are not copied, setting the size creates them.
+	return c.to.Truncate(size) //nolint:wrapcheck
+}
+
+// nextSegment finds the next data segment starting at off.
+func (c *fileCopier) nextSegment(
+	off, size int64,
+) (data, hole int64, err error) {
+	if c.noHoles {
+		return off, size, nil
+	}
+
+	fd := int(c.from.Fd())
+
+	data, err = syscall.Seek(fd, off, seekData)
+	if err != nil {
+		if errors.Is(err, syscall.ENXIO) {
+			return size, size, nil
+		}
+
+		// The file system doesn't support SEEK_DATA/SEEK_HOLE.
+		c.noHoles = true
+
+		return off, size, nil
+	}
+
+	hole, err = syscall.Seek(fd, data, seekHole)
+	if err != nil {
+		return 0, 0, os.NewSyscallError("lseek", err)
+	}
+
+	if hole > size {
+		hole = size
+	}
+
+	return data, hole, nil
+}
+
+// copyRange copies n bytes from off, the same offset is used for both files.
+// The fastest available method is used, if one of them is not supported, the
+// next one will be used for the rest of the copy.
+func (c *fileCopier) copyRange(off, n int64) error {
+	for n > 0 {
+		var (
+			written int64
+			err     error
+		)
+
+		switch c.method {
+		case copyFileRange:
+			written, err = c.copyFileRange(off, n)
+		case copySendfile:
+			written, err = c.sendfile(off, n)
+		default:
+			return c.copyUserspace(off, n)
+		}
+
+		if err != nil {
+			if c.method != copyUserspace && isCopyUnsupported(err) {
+				c.method++
+				continue
+			}
+
+			return err
+		}
+
+		if written == 0 {
+			return io.ErrUnexpectedEOF
+		}
+
+		off += written
+		n -= written
+	}
+
+	return nil
+}
+
+func (c *fileCopier) copyFileRange(off, n int64) (int64, error) {
+	inOff, outOff := off, off
+
+	written, _, errno := syscall.Syscall6(
+		sysCopyFileRange,
+		c.from.Fd(),
+		uintptr(unsafe.Pointer(&inOff)),
+		c.to.Fd(),
+		uintptr(unsafe.Pointer(&outOff)),
+		uintptr(chunkSize(n)),
+		0,
+	)
+
+	if errno != 0 {
+		return 0, os.NewSyscallError("copy_file_range", errno)
+	}
+
+	return int64(written), nil
+}
+
+func (c *fileCopier) sendfile(off, n int64) (int64, error) {
+	if _, err := c.to.Seek(off, io.SeekStart); err != nil {
+		return 0, err //nolint:wrapcheck
+	}
+
+	inOff := off
+
+	written, err := syscall.Sendfile(
+		int(c.to.Fd()),
+		int(c.from.Fd()),
+		&inOff,
+		chunkSize(n),
+	)
+
+	if err != nil {
+		return 0, os.NewSyscallError("sendfile", err)
+	}
+
+	return int64(written), nil
+}
+
+func (c *fileCopier) copyUserspace(off, n int64) error {
+	if _, err := c.to.Seek(off, io.SeekStart); err != nil {
+		return err //nolint:wrapcheck
+	}
+
+	// Hides io.ReaderFrom from c.to, so the stdlib doesn't try to use kernel
+	// space copies again.
+	w := struct{ io.Writer }{c.to}
+	r := io.NewSectionReader(c.from, off, n)
+
+	if _, err := io.Copy(w, r); err != nil {
+		return err //nolint:wrapcheck
+	}
+
+	return nil
+}
+
+func chunkSize(n int64) int {
+	if n > maxCopyChunk {
+		return maxCopyChunk
+	}
+
+	return int(n)
+}
+
+// isCopyUnsupported reports if err means that the used copy method is not
+// supported for the given files.
+func isCopyUnsupported(err error) bool {
+	var errno syscall.Errno
+	if !errors.As(err, &errno) {
+		return false
+	}
+
+	switch errno { //nolint:exhaustive
+	case syscall.ENOSYS, syscall.EXDEV, syscall.EINVAL, syscall.EOPNOTSUPP,
+		syscall.EPERM, syscall.EBADF:
+		return true
+	}
+
+	return false
+}
@@ -0,0 +1,125 @@
+// Copyright 2026 Miguel Angel Rivera Notararigo. All rights reserved.
+// This source code was released under the MIT license.
+
+package os_test
+
+import (
+	"os"
+	"path/filepath"
+	"syscall"
+	"testing"
+
+	ntos "go.ntrrg.dev/ntgo/os"
+)
+
+func TestCopyFile_sparse(t *testing.T) {
+	t.Parallel()
+
+	dir, err := os.MkdirTemp("", "ntgo-os-copy-file_sparse")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	defer os.RemoveAll(dir)
+
+	src := filepath.Join(dir, "source.bin")
+
+	f, err := os.Create(src)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// data, 1 MiB hole, data, 1 MiB hole.
+	const hole = 1 << 20
+
+	if _, err := f.WriteAt([]byte("head"), 0); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := f.WriteAt([]byte("middle"), hole); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := f.Truncate(2*hole + 6); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := f.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	if allocated(t, src) >= hole {
+		t.Skip("sparse files are not supported by the file system")
+	}
+
+	copyWith := func(m ntos.CopyMethod) func(dst string) error {
+		return func(dst string) error {
+			from, err := os.Open(src)
+			if err != nil {
+				return err
+			}
+
+			defer from.Close()
+
+			to, err := os.Create(dst)
+			if err != nil {
+				return err
+			}
+
+			defer to.Close()
+
+			return ntos.CopyData(to, from, m)
+		}
+	}
+
+	cases := []struct {
+		label string
+		copy  func(dst string) error
+	}{
+		{
+			label: "CopyFile",
+			copy: func(dst string) error {
+				opt := ntos.WithReflink(ntos.ReflinkNever)
+
+				return ntos.CopyFile(dst, src, 0o644, opt)
+			},
+		},
+		{label: "copy_file_range", copy: copyWith(ntos.CopyFileRange)},
+		{label: "sendfile", copy: copyWith(ntos.CopySendfile)},
+		{label: "userspace", copy: copyWith(ntos.CopyUserspace)},
+	}
+
+	for _, c := range cases {
+		dst := filepath.Join(dir, c.label+".bin")
+
+		if err := c.copy(dst); err != nil {
+			t.Errorf("[%s] cannot copy a sparse file: %v", c.label, err)
+			continue
+		}
+
+		if err := compareFiles(dst, src); err != nil {
+			t.Errorf("[%s] %v", c.label, err)
+		}
+
+		if n := allocated(t, dst); n >= hole {
+			t.Errorf("[%s] holes were not preserved, %d bytes allocated", c.label, n)
+		}
+	}
+}
+
+// allocated returns the amount of bytes allocated for the file at path.
+func allocated(t *testing.T, path string) int64 {
+	t.Helper()
+
+	fi, err := os.Stat(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	st, ok := fi.Sys().(*syscall.Stat_t)
+	if !ok {
+		t.Fatalf("cannot get allocated blocks of %s", path)
+	}
+
+	return st.Blocks * 512
+}
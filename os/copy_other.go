@@ -0,0 +1,21 @@
+// Copyright 2026 Miguel Angel Rivera Notararigo. All rights reserved.
+// This source code was released under the MIT license.
+
+//go:build !linux
+
+package os
+
+import (
+	"io"
+	"os"
+)
+
+func reflink(to, from *os.File) error {
+	return ErrNotSupported
+}
+
+func copyData(to, from *os.File) error {
+	_, err := io.Copy(to, from)
+
+	return err //nolint:wrapcheck
+}
@@ -7,11 +7,14 @@ import (
 	"bytes"
 	"errors"
 	"fmt"
+	"io/fs"
 	"os"
 	"path/filepath"
 	"strings"
 	"testing"
+	"testing/fstest"
 
+	nterrors "go.ntrrg.dev/ntgo/errors"
 	ntos "go.ntrrg.dev/ntgo/os"
 )
 
@@ -165,20 +168,190 @@ func TestCopyDir_multipleNonExistentElementsInDestPath(t *testing.T) {
 	}
 }
 
+func TestCopyDir_options(t *testing.T) {
+	t.Parallel()
+
+	dir, err := os.MkdirTemp("", "ntgo-os-copy-dir_options")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	defer os.RemoveAll(dir)
+
+	src := filepath.Join(dir, "source")
+	dst := filepath.Join(dir, "destination")
+
+	writeTree(t, src, map[string]string{
+		"file.txt":        "hello, world!",
+		"file.tmp":        "temporary",
+		"cache/file.txt":  "cached",
+		"sub/file.txt":    "nested",
+		"sub/skipped.tmp": "temporary",
+	})
+
+	if err := os.Chmod(filepath.Join(src, "file.txt"), 0o666); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := os.Symlink("file.txt", filepath.Join(src, "link")); err != nil {
+		t.Fatal(err)
+	}
+
+	err = ntos.CopyDir(
+		dst, src, 0o700,
+		ntos.WithFilter(ntos.Exclude("*.tmp", "cache")),
+		ntos.WithSymlinks(ntos.SymlinkPreserve),
+		ntos.WithUmask(0o022),
+	)
+
+	if err != nil {
+		t.Fatalf("CopyDir failed to copy a valid directory: %v", err)
+	}
+
+	for _, name := range []string{"file.tmp", "cache", "sub/skipped.tmp"} {
+		if _, err := os.Lstat(filepath.Join(dst, name)); err == nil {
+			t.Errorf("excluded file %s was copied", name)
+		}
+	}
+
+	if target, err := os.Readlink(filepath.Join(dst, "link")); err != nil {
+		t.Errorf("symbolic link was not preserved: %v", err)
+	} else if target != "file.txt" {
+		t.Errorf("invalid link target: %q", target)
+	}
+
+	fi, err := os.Stat(filepath.Join(dst, "file.txt"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if perm := fi.Mode().Perm(); perm != 0o644 {
+		t.Errorf("invalid permissions. got: %#o, want: %#o", perm, 0o644)
+	}
+}
+
+func TestCopyDir_symlinks(t *testing.T) {
+	t.Parallel()
+
+	dir, err := os.MkdirTemp("", "ntgo-os-copy-dir_symlinks")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	defer os.RemoveAll(dir)
+
+	src := filepath.Join(dir, "source")
+	dst := filepath.Join(dir, "destination")
+
+	writeTree(t, dir, map[string]string{
+		"source/sub/file.txt": "nested",
+		"other/file.txt":      "external",
+	})
+
+	links := map[string]string{
+		"linkdir":     "sub",
+		"sub/linkext": "../../other",
+	}
+
+	for name, target := range links {
+		if err := os.Symlink(target, filepath.Join(src, name)); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if err := ntos.Copy(dst, src); err != nil {
+		t.Fatalf("Copy failed to copy a directory with symbolic links: %v", err)
+	}
+
+	want := map[string]string{
+		"sub/file.txt":             "nested",
+		"sub/linkext/file.txt":     "external",
+		"linkdir/file.txt":         "nested",
+		"linkdir/linkext/file.txt": "external",
+	}
+
+	for name, data := range want {
+		got, err := os.ReadFile(filepath.Join(dst, name))
+		if err != nil || string(got) != data {
+			t.Errorf(
+				"[%s] invalid content. got: %q (%v), want: %q",
+				name, got, err, data,
+			)
+		}
+	}
+
+	if fi, err := os.Lstat(filepath.Join(dst, "linkdir")); err != nil {
+		t.Error(err)
+	} else if !fi.IsDir() {
+		t.Errorf("symbolic link was not followed: %v", fi.Mode())
+	}
+}
+
+func TestCopyDir_symlinkLoop(t *testing.T) {
+	t.Parallel()
+
+	dir, err := os.MkdirTemp("", "ntgo-os-copy-dir_symlink-loop")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	defer os.RemoveAll(dir)
+
+	cases := map[string]map[string]string{
+		"Ancestor": {"sub/loop": ".."},
+		"Itself":   {"sub/loop": "."},
+		"Mutual":   {"a/b": "../b", "b/a": "../a"},
+	}
+
+	for label, links := range cases {
+		src := filepath.Join(dir, label, "source")
+		dst := filepath.Join(dir, label, "destination")
+
+		for name, target := range links {
+			path := filepath.Join(src, name)
+
+			if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+				t.Fatal(err)
+			}
+
+			if err := os.Symlink(target, path); err != nil {
+				t.Fatal(err)
+			}
+		}
+
+		err := ntos.Copy(dst, src)
+		if !errors.Is(err, ntos.ErrCopySymlink) {
+			t.Errorf(
+				"[%s] invalid error. got: %v, want: %v",
+				label, err, ntos.ErrCopySymlink,
+			)
+		}
+	}
+}
+
 func TestCopyError_Error(t *testing.T) {
 	t.Parallel()
 
 	src := "source.txt"
 	dst := "destination.txt"
-	reason := "can't find the source file"
-	err := ntos.NewCopyError(src, dst, reason, os.ErrNotExist)
+	err := ntos.NewCopyError(ntos.ErrCopyOpenSrc, src, dst, os.ErrNotExist)
 	got := err.Error()
-	want := "cannot copy " + src + " to " + dst + ", " + reason
-	want += ": " + os.ErrNotExist.Error()
+	want := "[" + ntos.ErrCopyOpenSrc.Code() + "] "
+	want += ntos.ErrCopyOpenSrc.Reason() + ": "
+	want += src + " -> " + dst + ": " + os.ErrNotExist.Error()
 
 	if got != want {
 		t.Errorf("invalid error. got: %s, want: %s", got, want)
 	}
+
+	perr, errP := nterrors.Parse(got)
+	if errP != nil {
+		t.Fatalf("cannot parse error: %v", errP)
+	}
+
+	if !errors.Is(perr, ntos.ErrCopyOpenSrc) || !nterrors.Of(perr, ntos.ErrCopy) {
+		t.Errorf("invalid parsed error. got: %v", perr)
+	}
 }
 
 func TestCopyError_Unwrap(t *testing.T) {
@@ -186,21 +359,146 @@ func TestCopyError_Unwrap(t *testing.T) {
 
 	src := "source.txt"
 	dst := "destination.txt"
-	reason := "can't find the source file"
-	err := ntos.NewCopyError(src, dst, reason, os.ErrNotExist)
+	err := ntos.NewCopyError(ntos.ErrCopyOpenSrc, src, dst, os.ErrNotExist)
 
 	if !errors.Is(err, os.ErrNotExist) {
 		t.Errorf("invalid wrapped error. got: %v, want: %v", err, os.ErrNotExist)
 	}
 
-	reason = "cannot copy a directory into itself"
-	err = ntos.NewCopyError(src, dst, reason, nil)
+	var cerr *ntos.CopyError
+	if !errors.As(err, &cerr) || cerr.Src != src || cerr.Dst != dst {
+		t.Errorf("invalid copy error. got: %#v", cerr)
+	}
+
+	err = ntos.NewCopyError(ntos.ErrCopySelf, src, dst, nil)
 
-	if errors.Unwrap(err) != nil {
+	if !errors.As(err, &cerr) || errors.Unwrap(cerr) != nil {
 		t.Error("invalid error, should not be wrapping another error")
 	}
 }
 
+func TestCopyErrors(t *testing.T) {
+	t.Parallel()
+
+	dir, err := os.MkdirTemp("", "ntgo-os-copy-errors")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	defer os.RemoveAll(dir)
+
+	src := filepath.Join(dir, "source")
+	writeTree(t, src, map[string]string{"file.txt": "hello, world!"})
+
+	cases := []struct {
+		label string
+		err   error
+		want  error
+	}{
+		{
+			label: "Stat",
+			err:   ntos.Copy(filepath.Join(dir, "dst"), filepath.Join(dir, "none")),
+			want:  ntos.ErrCopyStat,
+		},
+		{
+			label: "Self copy",
+			err:   ntos.CopyDir(filepath.Join(src, "sub"), src, 0o700),
+			want:  ntos.ErrCopySelf,
+		},
+		{
+			label: "Open source",
+			err:   ntos.CopyFile(filepath.Join(dir, "dst"), "", 0o600),
+			want:  ntos.ErrCopyOpenSrc,
+		},
+		{
+			label: "Open destination",
+			err:   ntos.CopyFile("", filepath.Join(src, "file.txt"), 0o600),
+			want:  ntos.ErrCopyOpenDst,
+		},
+	}
+
+	for _, c := range cases {
+		if !errors.Is(c.err, c.want) {
+			t.Errorf("[%s] invalid error. got: %v, want: %v", c.label, c.err, c.want)
+		}
+
+		if !nterrors.Of(c.err, ntos.ErrCopy) {
+			t.Errorf("[%s] error is not a copy error. got: %v", c.label, c.err)
+		}
+	}
+}
+
+func TestCopyFS(t *testing.T) {
+	t.Parallel()
+
+	dir, err := os.MkdirTemp("", "ntgo-os-copy-fs")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	defer os.RemoveAll(dir)
+
+	fsys := fstest.MapFS{
+		"file.txt":        {Data: []byte("hello, world!"), Mode: 0o444},
+		"file.tmp":        {Data: []byte("temporary")},
+		"sub":             {Mode: fs.ModeDir | 0o555},
+		"sub/file.txt":    {Data: []byte("nested"), Mode: 0o644},
+		"sub/skipped.tmp": {Data: []byte("temporary")},
+	}
+
+	dst := filepath.Join(dir, "destination")
+	m := ntos.Manifest{}
+
+	err = ntos.CopyFS(
+		dst, fsys,
+		ntos.WithFilter(ntos.Exclude("*.tmp")),
+		ntos.WithManifest(m),
+	)
+
+	if err != nil {
+		t.Fatalf("CopyFS failed to copy a valid file system: %v", err)
+	}
+
+	for name, want := range map[string]string{
+		"file.txt":     "hello, world!",
+		"sub/file.txt": "nested",
+	} {
+		data, err := os.ReadFile(filepath.Join(dst, name))
+		if err != nil {
+			t.Errorf("cannot read %s: %v", name, err)
+		} else if string(data) != want {
+			t.Errorf("invalid content. got: %q, want: %q", data, want)
+		}
+	}
+
+	for _, name := range []string{"file.tmp", "sub/skipped.tmp"} {
+		if _, err := os.Lstat(filepath.Join(dst, name)); err == nil {
+			t.Errorf("excluded file %s was copied", name)
+		}
+	}
+
+	fi, err := os.Stat(filepath.Join(dst, "sub"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if perm := fi.Mode().Perm(); perm != 0o755 {
+		t.Errorf("invalid permissions. got: %#o, want: %#o", perm, 0o755)
+	}
+
+	if got := strings.Join(m.Paths(), ", "); got != "file.txt, sub/file.txt" {
+		t.Errorf("invalid manifest paths: %q", got)
+	}
+
+	// Reflinks are not supported.
+	opt := ntos.WithReflink(ntos.ReflinkAlways)
+	dst = filepath.Join(dir, "reflink")
+
+	if err := ntos.CopyFS(dst, fsys, opt); !errors.Is(err, ntos.ErrCopyClone) {
+		t.Errorf("invalid error. got: %v, want: %v", err, ntos.ErrCopyClone)
+	}
+}
+
 func TestCopyFile(t *testing.T) {
 	t.Parallel()
 
@@ -278,6 +576,65 @@ func TestCopyFile_empty(t *testing.T) {
 	}
 }
 
+func TestCopyFile_reflink(t *testing.T) {
+	t.Parallel()
+
+	dir, err := os.MkdirTemp("", "ntgo-os-copy-file_reflink")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	defer os.RemoveAll(dir)
+
+	src := filepath.Join(dir, "source.txt")
+	if err := os.WriteFile(src, []byte("hello, world!"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	m := ntos.Manifest{}
+
+	cases := []struct {
+		label string
+		mode  ntos.ReflinkMode
+		opts  []ntos.CopyOption
+	}{
+		{label: "Auto", mode: ntos.ReflinkAuto},
+		{label: "Always", mode: ntos.ReflinkAlways},
+		{label: "Never", mode: ntos.ReflinkNever},
+
+		// Digests don't disable required clones.
+		{
+			label: "AlwaysHashed", mode: ntos.ReflinkAlways,
+			opts: []ntos.CopyOption{ntos.WithVerify(), ntos.WithManifest(m)},
+		},
+	}
+
+	for _, c := range cases {
+		dst := filepath.Join(dir, c.label+".txt")
+		opts := append([]ntos.CopyOption{ntos.WithReflink(c.mode)}, c.opts...)
+
+		err := ntos.CopyFile(dst, src, 0o600, opts...)
+		if err != nil {
+			// Reflinks depend on the file system where tests are run.
+			if c.mode == ntos.ReflinkAlways && errors.Is(err, ntos.ErrCopyClone) {
+				continue
+			}
+
+			t.Errorf("[%s] CopyFile failed to copy a valid file: %v", c.label, err)
+
+			continue
+		}
+
+		if err := compareFiles(dst, src); err != nil {
+			t.Errorf("[%s] %v", c.label, err)
+		}
+
+		if c.opts != nil && m[c.label+".txt"] == nil {
+			t.Errorf("[%s] file was not added to the manifest", c.label)
+		}
+	}
+}
+
 func compareDirs(dst, src string) error {
 	fn := func(path string, info os.FileInfo, err error) error {
 		if err != nil {
@@ -2,6 +2,21 @@
 // This source code was released under the MIT license.
 
 // Package env provides environment variable access and manipulation.
+//
+// # Secret files
+//
+// Secrets are usually mounted as files (e.g. Docker and Kubernetes secrets),
+// so every function retrieving variables supports reading values from files.
+// If the variable K_FILE is defined, the value of K is read from the file at
+// the path it holds. Surrounding spaces are removed from the file content,
+// which can't be bigger than MaxSecretFileSize. Defining both K and K_FILE is
+// reported as ErrConflict.
+//
+//	// DB_PASSWORD_FILE=/run/secrets/db
+//	pass, err := env.Lookup("DB_PASSWORD", env.AsSecret(env.String))
+//
+// Secret values should be decoded as Secret (see AsSecret), which redacts its
+// value when formatted, and from decoding errors.
 package env
 
 // API Status: unstable
@@ -0,0 +1,501 @@
+// Copyright 2026 Miguel Angel Rivera Notararigo. All rights reserved.
+// This source code was released under the MIT license.
+
+package env
+
+import (
+	"errors"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"syscall"
+	"unicode/utf8"
+)
+
+// Dotenv holds the variables from a dotenv file. It implements Source, so it
+// may be used with GetFrom, LookupFrom and similar functions.
+//
+// # Syntax
+//
+// Every line holds a variable assignment, optionally prefixed by "export".
+// Empty lines and lines starting with "#" are ignored.
+//
+//	# Comment.
+//	KEY=value
+//	export KEY=value
+//
+// Surrounding spaces are removed from unquoted values, which end at the line
+// end or at a "#" preceded by a space, starting a comment.
+//
+// Values between single quotes are used literally and may span multiple
+// lines.
+//
+// Values between double quotes may span multiple lines and support the escape
+// sequences \n, \r, \t, \", \\ and \$.
+//
+// Unquoted and double-quoted values may reference other variables with $KEY,
+// ${KEY} or ${KEY:-default}, where default is used if KEY is absent or empty.
+// References are resolved with the variables defined before in the file, and
+// the process environment after.
+type Dotenv struct {
+	keys []string
+	vars map[string]string
+}
+
+// ParseDotenv parses a dotenv file from r. Syntax errors are reported as
+// ErrDotenv errors wrapping a SyntaxError.
+func ParseDotenv(r io.Reader) (*Dotenv, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, ErrDotenv.Wrap(err)
+	}
+
+	p := dotenvParser{
+		src:  string(data),
+		line: 1,
+		col:  1,
+		env:  &Dotenv{vars: map[string]string{}},
+	}
+
+	if err := p.parse(); err != nil {
+		return nil, err
+	}
+
+	return p.env, nil
+}
+
+// ReadDotenv parses the dotenv file at path, see ParseDotenv.
+func ReadDotenv(path string) (*Dotenv, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, ErrDotenv.Wrap(err)
+	}
+
+	defer f.Close()
+
+	d, err := ParseDotenv(f)
+	if err != nil {
+		var serr *SyntaxError
+		if errors.As(err, &serr) {
+			serr.File = path
+		}
+
+		return nil, err
+	}
+
+	return d, nil
+}
+
+// LoadDotenv sets the variables from the dotenv files at paths in the process
+// environment. Variables that are already defined are not modified, so files
+// should be given from the highest to the lowest priority.
+func LoadDotenv(paths ...string) error {
+	for _, path := range paths {
+		d, err := ReadDotenv(path)
+		if err != nil {
+			return err
+		}
+
+		if err := d.Setenv(false); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Keys returns the variable names in definition order.
+func (d *Dotenv) Keys() []string {
+	return append([]string(nil), d.keys...)
+}
+
+// Lookup implements Source.
+func (d *Dotenv) Lookup(k string) (string, bool) {
+	v, ok := d.vars[k]
+	return v, ok
+}
+
+// Setenv sets the variables from d in the process environment. If override is
+// false, variables that are already defined are not modified.
+func (d *Dotenv) Setenv(override bool) error {
+	for _, k := range d.keys {
+		if _, ok := syscall.Getenv(k); ok && !override {
+			continue
+		}
+
+		if err := os.Setenv(k, d.vars[k]); err != nil {
+			return ErrDotenv.Wrap(err)
+		}
+	}
+
+	return nil
+}
+
+func (d *Dotenv) set(k, v string) {
+	if _, ok := d.vars[k]; !ok {
+		d.keys = append(d.keys, k)
+	}
+
+	d.vars[k] = v
+}
+
+// SyntaxError describes a dotenv syntax error.
+type SyntaxError struct {
+	// File is the dotenv file path, it is empty if unknown.
+	File string
+
+	// Line and Column are 1-based, columns count characters.
+	Line, Column int
+
+	Msg string
+}
+
+// Error implements the error interface.
+func (e *SyntaxError) Error() string {
+	pos := strconv.Itoa(e.Line) + ":" + strconv.Itoa(e.Column)
+
+	if e.File != "" {
+		pos = e.File + ":" + pos
+	}
+
+	return pos + ": " + e.Msg
+}
+
+type dotenvParser struct {
+	src       string
+	pos       int
+	line, col int
+	env       *Dotenv
+}
+
+func (p *dotenvParser) parse() error {
+	for {
+		p.skipSpaces()
+
+		switch r := p.peek(); {
+		case r == -1:
+			return nil
+		case r == '\n' || r == '\r':
+			p.next()
+			continue
+		case r == '#':
+			p.skipLine()
+			continue
+		}
+
+		if err := p.parseAssignment(); err != nil {
+			return err
+		}
+	}
+}
+
+func (p *dotenvParser) parseAssignment() error {
+	line, col := p.line, p.col
+
+	k := p.readKey()
+	if k == "" {
+		return p.fail("invalid variable name")
+	}
+
+	if k == "export" && (p.peek() == ' ' || p.peek() == '\t') {
+		p.skipSpaces()
+
+		line, col = p.line, p.col
+		if k = p.readKey(); k == "" {
+			return p.fail("invalid variable name")
+		}
+	}
+
+	p.skipSpaces()
+
+	if p.peek() != '=' {
+		return p.failAt(line, col, "missing '=' after variable name")
+	}
+
+	p.next()
+	p.skipSpaces()
+
+	var (
+		v   string
+		err error
+	)
+
+	switch p.peek() {
+	case '\'':
+		v, err = p.readSingleQuoted()
+	case '"':
+		v, err = p.readDoubleQuoted()
+	default:
+		v, err = p.readUnquoted()
+	}
+
+	if err != nil {
+		return err
+	}
+
+	p.skipSpaces()
+
+	switch p.peek() {
+	case '#':
+		p.skipLine()
+	case '\n', '\r', -1:
+	default:
+		return p.fail("unexpected character after value")
+	}
+
+	p.env.set(k, v)
+
+	return nil
+}
+
+func (p *dotenvParser) readKey() string {
+	start := p.pos
+
+	for {
+		r := p.peek()
+		if !isKeyChar(r) || (p.pos == start && r >= '0' && r <= '9') {
+			break
+		}
+
+		p.next()
+	}
+
+	return p.src[start:p.pos]
+}
+
+func (p *dotenvParser) readSingleQuoted() (string, error) {
+	line, col := p.line, p.col
+	p.next()
+
+	i := strings.IndexByte(p.src[p.pos:], '\'')
+	if i < 0 {
+		return "", p.failAt(line, col, "unterminated single-quoted value")
+	}
+
+	v := p.src[p.pos : p.pos+i]
+	p.advance(i + 1)
+
+	return v, nil
+}
+
+func (p *dotenvParser) readDoubleQuoted() (string, error) {
+	line, col := p.line, p.col
+	p.next()
+
+	var b strings.Builder
+
+	for {
+		switch r := p.peek(); r {
+		case -1:
+			return "", p.failAt(line, col, "unterminated double-quoted value")
+		case '"':
+			p.next()
+			return b.String(), nil
+		case '\\':
+			if err := p.readEscape(&b); err != nil {
+				return "", err
+			}
+		case '$':
+			if err := p.readRef(&b); err != nil {
+				return "", err
+			}
+		default:
+			b.WriteRune(r)
+			p.next()
+		}
+	}
+}
+
+func (p *dotenvParser) readEscape(b *strings.Builder) error {
+	line, col := p.line, p.col
+	p.next()
+
+	switch r := p.peek(); r {
+	case 'n':
+		b.WriteByte('\n')
+	case 'r':
+		b.WriteByte('\r')
+	case 't':
+		b.WriteByte('\t')
+	case '"', '\\', '$':
+		b.WriteRune(r)
+	default:
+		return p.failAt(line, col, "invalid escape sequence")
+	}
+
+	p.next()
+
+	return nil
+}
+
+func (p *dotenvParser) readUnquoted() (string, error) {
+	var b strings.Builder
+
+	for {
+		r := p.peek()
+
+		switch {
+		case r == -1 || r == '\n' || r == '\r':
+			return strings.TrimRight(b.String(), " \t"), nil
+		case r == '#' && p.pos > 0 && isSpace(p.src[p.pos-1]):
+			return strings.TrimRight(b.String(), " \t"), nil
+		case r == '$':
+			if err := p.readRef(&b); err != nil {
+				return "", err
+			}
+		default:
+			b.WriteRune(r)
+			p.next()
+		}
+	}
+}
+
+// readRef reads a variable reference and writes its value into b.
+func (p *dotenvParser) readRef(b *strings.Builder) error {
+	line, col := p.line, p.col
+	p.next()
+
+	if p.peek() != '{' {
+		k := p.readRefName()
+		if k == "" {
+			b.WriteByte('$')
+			return nil
+		}
+
+		v, _ := p.lookup(k)
+		b.WriteString(v)
+
+		return nil
+	}
+
+	p.next()
+
+	k := p.readRefName()
+	if k == "" {
+		return p.failAt(line, col, "invalid variable reference")
+	}
+
+	var def string
+
+	if strings.HasPrefix(p.src[p.pos:], ":-") {
+		p.advance(2)
+
+		i := strings.IndexAny(p.src[p.pos:], "}\n")
+		if i < 0 || p.src[p.pos+i] != '}' {
+			return p.failAt(line, col, "unterminated variable reference")
+		}
+
+		def = p.src[p.pos : p.pos+i]
+		p.advance(i)
+	}
+
+	if p.peek() != '}' {
+		return p.failAt(line, col, "unterminated variable reference")
+	}
+
+	p.next()
+
+	v, _ := p.lookup(k)
+	if v == "" {
+		v = def
+	}
+
+	b.WriteString(v)
+
+	return nil
+}
+
+func (p *dotenvParser) readRefName() string {
+	start := p.pos
+
+	for {
+		r := p.peek()
+		if r != '_' && !isAlnum(r) {
+			break
+		}
+
+		p.next()
+	}
+
+	return p.src[start:p.pos]
+}
+
+func (p *dotenvParser) lookup(k string) (string, bool) {
+	if v, ok := p.env.Lookup(k); ok {
+		return v, true
+	}
+
+	return syscall.Getenv(k)
+}
+
+// peek returns the current character, or -1 at the end of the input.
+func (p *dotenvParser) peek() rune {
+	if p.pos >= len(p.src) {
+		return -1
+	}
+
+	r, _ := utf8.DecodeRuneInString(p.src[p.pos:])
+
+	return r
+}
+
+// next moves to the next character.
+func (p *dotenvParser) next() {
+	if p.pos >= len(p.src) {
+		return
+	}
+
+	r, size := utf8.DecodeRuneInString(p.src[p.pos:])
+	p.pos += size
+
+	if r == '\n' {
+		p.line++
+		p.col = 1
+	} else {
+		p.col++
+	}
+}
+
+// advance moves n bytes forward.
+func (p *dotenvParser) advance(n int) {
+	for end := p.pos + n; p.pos < end; {
+		p.next()
+	}
+}
+
+func (p *dotenvParser) skipLine() {
+	for r := p.peek(); r != -1 && r != '\n'; r = p.peek() {
+		p.next()
+	}
+}
+
+func (p *dotenvParser) skipSpaces() {
+	for r := p.peek(); r == ' ' || r == '\t'; r = p.peek() {
+		p.next()
+	}
+}
+
+func (p *dotenvParser) fail(msg string) error {
+	return p.failAt(p.line, p.col, msg)
+}
+
+func (p *dotenvParser) failAt(line, col int, msg string) error {
+	return ErrDotenvSyntax.Wrap(&SyntaxError{
+		Line:   line,
+		Column: col,
+		Msg:    msg,
+	})
+}
+
+func isAlnum(r rune) bool {
+	return r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9'
+}
+
+func isKeyChar(r rune) bool {
+	return r == '_' || r == '.' || isAlnum(r)
+}
+
+func isSpace(c byte) bool {
+	return c == ' ' || c == '\t'
+}
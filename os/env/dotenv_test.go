@@ -0,0 +1,174 @@
+// Copyright 2026 Miguel Angel Rivera Notararigo. All rights reserved.
+// This source code was released under the MIT license.
+
+package env_test
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"go.ntrrg.dev/ntgo/os/env"
+)
+
+const testDotenv = `# Comment.
+PLAIN=value
+SPACED =  spaced value  # Comment.
+export EXPORTED=exported
+EMPTY=
+HASH=a#b
+SINGLE='literal $PLAIN \n'
+DOUBLE="escaped\t\"$PLAIN\"\n"
+MULTILINE="first
+second"
+REF=${PLAIN}-$SPACED
+DEFAULT=${X_TEST_DOTENV_MISSING:-fallback}
+PROCESS=$X_TEST_DOTENV_PROCESS
+PORT=8080
+`
+
+func TestParseDotenv(t *testing.T) {
+	t.Setenv("X_TEST_DOTENV_PROCESS", "from process")
+
+	d, err := env.ParseDotenv(strings.NewReader(testDotenv))
+	if err != nil {
+		t.Fatalf("cannot parse dotenv: %v", err)
+	}
+
+	want := map[string]string{
+		"PLAIN":     "value",
+		"SPACED":    "spaced value",
+		"EXPORTED":  "exported",
+		"EMPTY":     "",
+		"HASH":      "a#b",
+		"SINGLE":    `literal $PLAIN \n`,
+		"DOUBLE":    "escaped\t\"value\"\n",
+		"MULTILINE": "first\nsecond",
+		"REF":       "value-spaced value",
+		"DEFAULT":   "fallback",
+		"PROCESS":   "from process",
+		"PORT":      "8080",
+	}
+
+	got := map[string]string{}
+
+	for _, k := range d.Keys() {
+		got[k], _ = d.Lookup(k)
+	}
+
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("invalid variables. got: %q, want: %q", got, want)
+	}
+
+	if keys := d.Keys(); keys[0] != "PLAIN" || keys[len(keys)-1] != "PORT" {
+		t.Errorf("invalid keys order: %q", keys)
+	}
+
+	port, err := env.LookupFrom(d, "PORT", env.Int)
+	if err != nil || port != 8080 {
+		t.Errorf("invalid value. got: %v (%v), want: %v", port, err, 8080)
+	}
+
+	if _, err := env.LookupFrom(d, "MISSING", env.String); err == nil {
+		t.Error("missing variable was found")
+	}
+}
+
+func TestParseDotenv_errors(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		label     string
+		in        string
+		line, col int
+	}{
+		{label: "Missing equal", in: "A=1\nKEY value", line: 2, col: 1},
+		{label: "Invalid name", in: "A=1\n  1KEY=value", line: 2, col: 3},
+		{label: "Unterminated quote", in: "A=1\nB=\"x\n\ny", line: 2, col: 3},
+		{label: "Invalid escape", in: `A="a\qb"`, line: 1, col: 5},
+		{label: "Trailing characters", in: "A='a' b", line: 1, col: 7},
+		{label: "Unterminated reference", in: "A=${B", line: 1, col: 3},
+		{label: "Multibyte column", in: "A=\"ñ\\q\"", line: 1, col: 5},
+	}
+
+	for _, c := range cases {
+		_, err := env.ParseDotenv(strings.NewReader(c.in))
+		if !errors.Is(err, env.ErrDotenvSyntax) {
+			t.Errorf("[%s] invalid error: %v", c.label, err)
+			continue
+		}
+
+		var serr *env.SyntaxError
+		if !errors.As(err, &serr) {
+			t.Errorf("[%s] missing syntax error: %v", c.label, err)
+			continue
+		}
+
+		if serr.Line != c.line || serr.Column != c.col {
+			t.Errorf(
+				"[%s] invalid position. got: %d:%d, want: %d:%d",
+				c.label, serr.Line, serr.Column, c.line, c.col,
+			)
+		}
+	}
+}
+
+func TestLoadDotenv(t *testing.T) {
+	dir, err := os.MkdirTemp("", "ntgo-os-env-dotenv")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	defer os.RemoveAll(dir)
+
+	local := filepath.Join(dir, ".env.local")
+	data := "X_TEST_DOTENV_LOCAL=local\nX_TEST_DOTENV_SET=local\n"
+
+	if err := os.WriteFile(local, []byte(data), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	path := filepath.Join(dir, ".env")
+	data = "X_TEST_DOTENV_LOCAL=default\nX_TEST_DOTENV_NEW=default\n"
+
+	if err := os.WriteFile(path, []byte(data), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	t.Setenv("X_TEST_DOTENV_SET", "process")
+	t.Setenv("X_TEST_DOTENV_LOCAL", "")
+	t.Setenv("X_TEST_DOTENV_NEW", "")
+	os.Unsetenv("X_TEST_DOTENV_LOCAL")
+	os.Unsetenv("X_TEST_DOTENV_NEW")
+
+	if err := env.LoadDotenv(local, path); err != nil {
+		t.Fatalf("cannot load dotenv files: %v", err)
+	}
+
+	for k, want := range map[string]string{
+		"X_TEST_DOTENV_SET":   "process",
+		"X_TEST_DOTENV_LOCAL": "local",
+		"X_TEST_DOTENV_NEW":   "default",
+	} {
+		if got := os.Getenv(k); got != want {
+			t.Errorf("invalid %s value. got: %q, want: %q", k, got, want)
+		}
+	}
+
+	if err := os.WriteFile(path, []byte("A=1\nB"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	var serr *env.SyntaxError
+
+	if err := env.LoadDotenv(path); !errors.As(err, &serr) {
+		t.Fatalf("invalid error: %v", err)
+	}
+
+	if want := path + ":2:1: "; !strings.HasPrefix(serr.Error(), want) {
+		t.Errorf("invalid error. got: %q, want prefix: %q", serr, want)
+	}
+}
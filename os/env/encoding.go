@@ -3,9 +3,317 @@
 
 package env
 
+import (
+	"errors"
+	"math"
+	"net"
+	"net/netip"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
 // Decoder is a plain text decoder for a specific type.
 type Decoder[T any] func(string) (T, error)
 
+// String returns v as is.
 func String(v string) (string, error) {
 	return v, nil
 }
+
+// Bool decodes v as a boolean, see strconv.ParseBool.
+func Bool(v string) (bool, error) {
+	return strconv.ParseBool(v) //nolint:wrapcheck
+}
+
+// Int decodes v as an int. Base prefixes are supported, see strconv.ParseInt.
+func Int(v string) (int, error) {
+	return parseInt[int](v, strconv.IntSize)
+}
+
+// Int8 decodes v as an int8.
+func Int8(v string) (int8, error) {
+	return parseInt[int8](v, 8)
+}
+
+// Int16 decodes v as an int16.
+func Int16(v string) (int16, error) {
+	return parseInt[int16](v, 16)
+}
+
+// Int32 decodes v as an int32.
+func Int32(v string) (int32, error) {
+	return parseInt[int32](v, 32)
+}
+
+// Int64 decodes v as an int64.
+func Int64(v string) (int64, error) {
+	return parseInt[int64](v, 64)
+}
+
+// Uint decodes v as an uint. Base prefixes are supported, see
+// strconv.ParseUint.
+func Uint(v string) (uint, error) {
+	return parseUint[uint](v, strconv.IntSize)
+}
+
+// Uint8 decodes v as an uint8.
+func Uint8(v string) (uint8, error) {
+	return parseUint[uint8](v, 8)
+}
+
+// Uint16 decodes v as an uint16.
+func Uint16(v string) (uint16, error) {
+	return parseUint[uint16](v, 16)
+}
+
+// Uint32 decodes v as an uint32.
+func Uint32(v string) (uint32, error) {
+	return parseUint[uint32](v, 32)
+}
+
+// Uint64 decodes v as an uint64.
+func Uint64(v string) (uint64, error) {
+	return parseUint[uint64](v, 64)
+}
+
+// Float32 decodes v as a float32.
+func Float32(v string) (float32, error) {
+	n, err := strconv.ParseFloat(v, 32)
+
+	return float32(n), err //nolint:wrapcheck
+}
+
+// Float64 decodes v as a float64.
+func Float64(v string) (float64, error) {
+	return strconv.ParseFloat(v, 64) //nolint:wrapcheck
+}
+
+// Duration decodes v as a time.Duration, see time.ParseDuration.
+func Duration(v string) (time.Duration, error) {
+	return time.ParseDuration(v) //nolint:wrapcheck
+}
+
+// Time returns a decoder for times with the given layout, see time.Parse.
+func Time(layout string) Decoder[time.Time] {
+	return func(v string) (time.Time, error) {
+		return time.Parse(layout, v) //nolint:wrapcheck
+	}
+}
+
+// URL decodes v as an absolute URL.
+func URL(v string) (*url.URL, error) {
+	u, err := url.Parse(v)
+	if err != nil {
+		return nil, err //nolint:wrapcheck
+	}
+
+	if !u.IsAbs() {
+		return nil, errors.New("'" + v + "' is not an absolute URL")
+	}
+
+	return u, nil
+}
+
+// IP decodes v as an IPv4 or IPv6 address.
+func IP(v string) (net.IP, error) {
+	ip := net.ParseIP(v)
+	if ip == nil {
+		return nil, errors.New("'" + v + "' is not a valid IP address")
+	}
+
+	return ip, nil
+}
+
+// Prefix decodes v as an IP network in CIDR notation, see
+// netip.ParsePrefix.
+func Prefix(v string) (netip.Prefix, error) {
+	return netip.ParsePrefix(v) //nolint:wrapcheck
+}
+
+// Bytes decodes v as a ByteSize.
+func Bytes(v string) (ByteSize, error) {
+	var b ByteSize
+
+	err := b.UnmarshalText([]byte(v))
+
+	return b, err
+}
+
+// List returns a decoder for lists separated by sep, every element is
+// decoded with fn. Surrounding spaces are removed from elements, empty values
+// are decoded as empty lists.
+func List[T any](sep string, fn Decoder[T]) Decoder[[]T] {
+	return func(v string) ([]T, error) {
+		if v == "" {
+			return []T{}, nil
+		}
+
+		items := strings.Split(v, sep)
+		l := make([]T, 0, len(items))
+
+		for _, item := range items {
+			e, err := fn(strings.TrimSpace(item))
+			if err != nil {
+				return nil, err
+			}
+
+			l = append(l, e)
+		}
+
+		return l, nil
+	}
+}
+
+// Map returns a decoder for "key=value" entries separated by sep. Keys are
+// decoded with kfn and values with vfn. Surrounding spaces are removed from
+// keys and values, empty entries are ignored.
+func Map[K comparable, V any](
+	sep string,
+	kfn Decoder[K],
+	vfn Decoder[V],
+) Decoder[map[K]V] {
+	return func(v string) (map[K]V, error) {
+		m := map[K]V{}
+
+		for _, entry := range strings.Split(v, sep) {
+			if strings.TrimSpace(entry) == "" {
+				continue
+			}
+
+			ks, vs, ok := strings.Cut(entry, "=")
+			if !ok {
+				return nil, errors.New("invalid map entry '" + entry + "'")
+			}
+
+			k, err := kfn(strings.TrimSpace(ks))
+			if err != nil {
+				return nil, err
+			}
+
+			val, err := vfn(strings.TrimSpace(vs))
+			if err != nil {
+				return nil, err
+			}
+
+			m[k] = val
+		}
+
+		return m, nil
+	}
+}
+
+// Enum returns a decoder that maps names to their values.
+func Enum[T any](values map[string]T) Decoder[T] {
+	return func(v string) (T, error) {
+		val, ok := values[v]
+		if ok {
+			return val, nil
+		}
+
+		names := make([]string, 0, len(values))
+		for name := range values {
+			names = append(names, name)
+		}
+
+		sort.Strings(names)
+
+		err := "'" + v + "' is not one of: " + strings.Join(names, ", ")
+
+		return val, errors.New(err)
+	}
+}
+
+// ByteSize is an amount of bytes. Its text form is a number followed by an
+// optional unit, decimal (kB, MB, GB, TB, PB, EB) and binary (KiB, MiB, GiB,
+// TiB, PiB, EiB) units are supported. Units are case insensitive and the "B"
+// suffix is optional, e.g. "512", "10MiB", "1.5g".
+type ByteSize int64
+
+// Byte sizes.
+const (
+	Byte ByteSize = 1
+
+	KB ByteSize = 1000 * Byte
+	MB ByteSize = 1000 * KB
+	GB ByteSize = 1000 * MB
+	TB ByteSize = 1000 * GB
+	PB ByteSize = 1000 * TB
+	EB ByteSize = 1000 * PB
+
+	KiB ByteSize = 1 << (10 * (iota - 6))
+	MiB
+	GiB
+	TiB
+	PiB
+	EiB
+)
+
+var byteUnits = map[string]ByteSize{
+	"": Byte, "b": Byte,
+	"k": KB, "kb": KB, "ki": KiB, "kib": KiB,
+	"m": MB, "mb": MB, "mi": MiB, "mib": MiB,
+	"g": GB, "gb": GB, "gi": GiB, "gib": GiB,
+	"t": TB, "tb": TB, "ti": TiB, "tib": TiB,
+	"p": PB, "pb": PB, "pi": PiB, "pib": PiB,
+	"e": EB, "eb": EB, "ei": EiB, "eib": EiB,
+}
+
+// String returns b in bytes.
+func (b ByteSize) String() string {
+	return strconv.FormatInt(int64(b), 10) + "B"
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (b *ByteSize) UnmarshalText(text []byte) error {
+	s := strings.TrimSpace(string(text))
+
+	i := strings.IndexFunc(s, func(r rune) bool {
+		return (r < '0' || r > '9') && r != '.'
+	})
+
+	if i < 0 {
+		i = len(s)
+	}
+
+	unit, ok := byteUnits[strings.ToLower(strings.TrimSpace(s[i:]))]
+	if !ok {
+		return errors.New("invalid byte size unit in '" + s + "'")
+	}
+
+	n, err := strconv.ParseFloat(s[:i], 64)
+	if err != nil {
+		return errors.New("invalid byte size '" + s + "'")
+	}
+
+	n *= float64(unit)
+	if n >= math.MaxInt64 {
+		return errors.New("byte size '" + s + "' is out of range")
+	}
+
+	*b = ByteSize(n)
+
+	return nil
+}
+
+type signed interface {
+	~int | ~int8 | ~int16 | ~int32 | ~int64
+}
+
+type unsigned interface {
+	~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64 | ~uintptr
+}
+
+func parseInt[T signed](v string, bits int) (T, error) {
+	n, err := strconv.ParseInt(v, 0, bits)
+
+	return T(n), err //nolint:wrapcheck
+}
+
+func parseUint[T unsigned](v string, bits int) (T, error) {
+	n, err := strconv.ParseUint(v, 0, bits)
+
+	return T(n), err //nolint:wrapcheck
+}
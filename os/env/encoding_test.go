@@ -0,0 +1,142 @@
+// Copyright 2026 Miguel Angel Rivera Notararigo. All rights reserved.
+// This source code was released under the MIT license.
+
+package env_test
+
+import (
+	"fmt"
+	"net/netip"
+	"reflect"
+	"testing"
+	"time"
+
+	"go.ntrrg.dev/ntgo/os/env"
+)
+
+func TestDecoders(t *testing.T) {
+	t.Parallel()
+
+	type level int
+
+	levels := map[string]level{"debug": 0, "info": 1}
+
+	cases := []struct {
+		label string
+		fn    func(string) (any, error)
+		in    string
+		want  any
+		fail  bool
+	}{
+		{label: "Bool", fn: dec(env.Bool), in: "true", want: true},
+		{label: "Bool invalid", fn: dec(env.Bool), in: "yes", fail: true},
+		{label: "Int", fn: dec(env.Int), in: "-42", want: -42},
+		{label: "Int hex", fn: dec(env.Int64), in: "0xff", want: int64(255)},
+		{label: "Int8 overflow", fn: dec(env.Int8), in: "128", fail: true},
+		{label: "Uint16", fn: dec(env.Uint16), in: "443", want: uint16(443)},
+		{label: "Uint negative", fn: dec(env.Uint), in: "-1", fail: true},
+		{label: "Float32", fn: dec(env.Float32), in: "0.5", want: float32(0.5)},
+		{
+			label: "Duration",
+			fn:    dec(env.Duration),
+			in:    "1m30s",
+			want:  90 * time.Second,
+		},
+		{
+			label: "Time",
+			fn:    dec(env.Time("2006-01-02")),
+			in:    "2026-01-02",
+			want:  time.Date(2026, 1, 2, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			label: "URL",
+			fn:    dec(env.URL),
+			in:    "https://example.com/path",
+			want:  "https://example.com/path",
+		},
+		{label: "URL relative", fn: dec(env.URL), in: "/path", fail: true},
+		{label: "IP", fn: dec(env.IP), in: "::1", want: "::1"},
+		{label: "IP invalid", fn: dec(env.IP), in: "localhost", fail: true},
+		{
+			label: "Prefix",
+			fn:    dec(env.Prefix),
+			in:    "10.0.0.0/8",
+			want:  netip.MustParsePrefix("10.0.0.0/8"),
+		},
+		{label: "Bytes", fn: dec(env.Bytes), in: "512", want: 512 * env.Byte},
+		{label: "Bytes binary", fn: dec(env.Bytes), in: "1MiB", want: env.MiB},
+		{
+			label: "Bytes decimal",
+			fn:    dec(env.Bytes),
+			in:    "1.5gb",
+			want:  1500 * env.MB,
+		},
+		{label: "Bytes invalid", fn: dec(env.Bytes), in: "10XB", fail: true},
+		{
+			label: "List",
+			fn:    dec(env.List(",", env.Int)),
+			in:    "1, 2,3",
+			want:  []int{1, 2, 3},
+		},
+		{
+			label: "List empty",
+			fn:    dec(env.List(",", env.String)),
+			in:    "",
+			want:  []string{},
+		},
+		{
+			label: "List invalid",
+			fn:    dec(env.List(",", env.Int)),
+			in:    "1,a",
+			fail:  true,
+		},
+		{
+			label: "Map",
+			fn:    dec(env.Map(";", env.String, env.Duration)),
+			in:    "read=1s; write=2s",
+			want: map[string]time.Duration{
+				"read":  time.Second,
+				"write": 2 * time.Second,
+			},
+		},
+		{
+			label: "Map invalid",
+			fn:    dec(env.Map(",", env.String, env.String)),
+			in:    "a=1,b",
+			fail:  true,
+		},
+		{label: "Enum", fn: dec(env.Enum(levels)), in: "info", want: level(1)},
+		{label: "Enum invalid", fn: dec(env.Enum(levels)), in: "x", fail: true},
+	}
+
+	for _, c := range cases {
+		got, err := c.fn(c.in)
+		if c.fail {
+			if err == nil {
+				t.Errorf("[%s] %q was decoded: %v", c.label, c.in, got)
+			}
+
+			continue
+		}
+
+		if err != nil {
+			t.Errorf("[%s] cannot decode %q: %v", c.label, c.in, err)
+			continue
+		}
+
+		if s, ok := got.(fmt.Stringer); ok {
+			if _, ok := c.want.(string); ok {
+				got = s.String()
+			}
+		}
+
+		if !reflect.DeepEqual(got, c.want) {
+			t.Errorf("[%s] invalid value. got: %v, want: %v", c.label, got, c.want)
+		}
+	}
+}
+
+func dec[T any](fn env.Decoder[T]) func(string) (any, error) {
+	return func(v string) (any, error) {
+		return fn(v)
+	}
+}
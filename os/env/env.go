@@ -5,13 +5,15 @@ package env
 
 import (
 	"errors"
-	"syscall"
 )
 
 var (
 	ErrGet = Err.New("get", "cannot get environment variable value")
 
 	ErrCannotDecode = ErrGet.New("decode", "cannot decode value")
+	ErrConflict     = ErrGet.New("conflict", "variable defined twice")
+	ErrInvalid      = ErrGet.New("invalid", "invalid value")
+	ErrSecretFile   = ErrGet.New("secret-file", "cannot read secret file")
 	ErrUndefined    = ErrGet.New("undefined", "variable not defined")
 )
 
@@ -30,8 +32,7 @@ func Must[T any](v T, err error) T {
 //
 // See Lookup for detecting unset environment variables.
 func Get[T any](k string, fn Decoder[T]) (T, error) {
-	v, _ := syscall.Getenv(k)
-	return decode(v, fn)
+	return GetFrom(Process, k, fn)
 }
 
 // GetOr retrieves the value of the environment variable k. If the variable is
@@ -39,12 +40,7 @@ func Get[T any](k string, fn Decoder[T]) (T, error) {
 //
 // See LookupOr for using v only if the environment variable is unset.
 func GetOr[T any](k string, v T, fn Decoder[T]) (T, error) {
-	_v, _ := syscall.Getenv(k)
-	if _v == "" {
-		return v, nil
-	}
-
-	return decode(_v, fn)
+	return GetOrFrom(Process, k, v, fn)
 }
 
 // Lookup retrieves the value of the environment variable k. If the variable is
@@ -52,14 +48,7 @@ func GetOr[T any](k string, v T, fn Decoder[T]) (T, error) {
 //
 // See Get for ignoring unset environment variables.
 func Lookup[T any](k string, fn Decoder[T]) (T, error) {
-	var v T
-
-	_v, ok := syscall.Getenv(k)
-	if !ok {
-		return v, ErrUndefined.Wrap(errors.New("'" + k + "' not found"))
-	}
-
-	return decode(_v, fn)
+	return LookupFrom(Process, k, fn)
 }
 
 // LookupOr retrieves the value of the environment variable k. If the variable
@@ -67,19 +56,19 @@ func Lookup[T any](k string, fn Decoder[T]) (T, error) {
 //
 // See GetOr for also using v when the environment variable is empty.
 func LookupOr[T any](k string, v T, fn Decoder[T]) (T, error) {
-	_v, ok := syscall.Getenv(k)
-	if !ok {
-		return v, nil
-	}
-
-	return decode(_v, fn)
+	return LookupOrFrom(Process, k, v, fn)
 }
 
-func decode[T any](val string, fn Decoder[T]) (v T, err error) {
+func decode[T any](k, val string, fn Decoder[T]) (v T, err error) {
 	v, err = fn(val)
-	if err != nil {
-		return v, ErrCannotDecode.Wrap(err)
+	if err == nil {
+		return v, nil
+	}
+
+	var verr *ValidationError
+	if errors.As(err, &verr) {
+		return v, ErrInvalid.Wrap(&VarError{Name: k, Err: err})
 	}
 
-	return v, nil
+	return v, ErrCannotDecode.Wrap(&VarError{Name: k, Err: err})
 }
@@ -9,3 +9,12 @@ import (
 
 // Err is the main error group for this package.
 var Err = ntos.Err.New("env", "env package errors")
+
+var ErrInvalidSpec = Err.New("spec", "invalid configuration specification")
+
+var (
+	ErrDotenv       = Err.New("dotenv", "cannot read dotenv file")
+	ErrDotenvSyntax = ErrDotenv.New("syntax", "invalid dotenv syntax")
+)
+
+var ErrReload = Err.New("reload", "cannot reload configuration")
@@ -0,0 +1,259 @@
+// Copyright 2026 Miguel Angel Rivera Notararigo. All rights reserved.
+// This source code was released under the MIT license.
+
+package env
+
+import (
+	"flag"
+	"fmt"
+	"reflect"
+
+	nterrors "go.ntrrg.dev/ntgo/errors"
+)
+
+// Binder binds settings to command-line flags and environment variables.
+// Values are taken from the first of these that defines them:
+//
+//  1. Command-line flags.
+//  2. Environment variables (process environment by default).
+//  3. Dotenv files (see WithBindDotenv).
+//  4. Default values.
+//
+// Settings are registered with Bind, and resolved by Parse.
+//
+//	b := env.NewBinder(flag.CommandLine, env.WithBindDotenv(dotenv))
+//	port := env.Bind(b, "port", "PORT", 8080, env.Int, "listening port")
+//
+//	if err := b.Parse(os.Args[1:]); err != nil {
+//		log.Fatal(err)
+//	}
+//
+//	log.Printf("port %d (from %s)", *port, b.Origin("port"))
+type Binder struct {
+	fs     *flag.FlagSet
+	env    Source
+	dotenv Source
+
+	settings []setting
+	names    map[string]setting
+}
+
+// NewBinder creates a binder that registers flags in fs.
+func NewBinder(fs *flag.FlagSet, opts ...BindOption) *Binder {
+	b := &Binder{fs: fs, env: Process, names: map[string]setting{}}
+
+	for _, opt := range opts {
+		opt(b)
+	}
+
+	return b
+}
+
+// Bind registers a setting in b and returns a pointer to its value, which is
+// def until b.Parse is called. name is the flag name and k is the
+// environment variable name, any of them may be empty for not using the
+// flag or the variable. Flags and variables are decoded with fn.
+//
+// Flag usage messages mention the variable, e.g. "listening port ($PORT)".
+// Boolean settings are registered as boolean flags, so they may be given
+// without value.
+func Bind[T any](
+	b *Binder,
+	name, k string,
+	def T,
+	fn Decoder[T],
+	usage string,
+) *T {
+	s := &bindSetting[T]{
+		p:      new(T),
+		fn:     fn,
+		env:    k,
+		origin: OriginDefault,
+	}
+
+	*s.p = def
+
+	if name != "" {
+		if k != "" {
+			usage += " ($" + k + ")"
+		}
+
+		b.fs.Var(s, name, usage)
+		b.names[name] = s
+	}
+
+	if k != "" {
+		b.names[k] = s
+	}
+
+	b.settings = append(b.settings, s)
+
+	return s.p
+}
+
+// Parse parses the command-line flags from args and resolves the values of
+// settings not given as flags. Flag errors are returned as reported by
+// flag.FlagSet.Parse, other problems are reported as an error group (see
+// go.ntrrg.dev/ntgo/errors.Group) of errors like the ones returned by Get.
+func (b *Binder) Parse(args []string) error {
+	if err := b.fs.Parse(args); err != nil {
+		return err //nolint:wrapcheck
+	}
+
+	var errs []error
+
+	for _, s := range b.settings {
+		if err := s.resolve(b.env, b.dotenv); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	if len(errs) > 0 {
+		return nterrors.Group(errs...)
+	}
+
+	return nil
+}
+
+// Origin reports where the value of a setting came from. name may be the
+// flag name or the variable name of the setting. If there is no setting
+// with the given name, OriginUnknown is returned.
+func (b *Binder) Origin(name string) Origin {
+	s, ok := b.names[name]
+	if !ok {
+		return OriginUnknown
+	}
+
+	return s.from()
+}
+
+// BindOption configures binders.
+type BindOption func(*Binder)
+
+// WithBindDotenv sets the source used for settings that are not defined as
+// flags or environment variables. It is usually a Dotenv, but any Source may
+// be used.
+func WithBindDotenv(src Source) BindOption {
+	return func(b *Binder) {
+		b.dotenv = src
+	}
+}
+
+// WithBindEnv sets the source used as environment. Default is Process.
+func WithBindEnv(src Source) BindOption {
+	return func(b *Binder) {
+		b.env = src
+	}
+}
+
+// Origin is the place where the value of a setting came from.
+type Origin int
+
+const (
+	OriginUnknown Origin = iota
+	OriginDefault
+	OriginDotenv
+	OriginEnv
+	OriginFlag
+)
+
+// String implements fmt.Stringer.
+func (o Origin) String() string {
+	switch o {
+	case OriginDefault:
+		return "default"
+	case OriginDotenv:
+		return "dotenv"
+	case OriginEnv:
+		return "env"
+	case OriginFlag:
+		return "flag"
+	case OriginUnknown:
+	}
+
+	return "unknown"
+}
+
+type setting interface {
+	from() Origin
+	resolve(env, dotenv Source) error
+}
+
+// bindSetting is a setting that implements flag.Value.
+type bindSetting[T any] struct {
+	p      *T
+	fn     Decoder[T]
+	env    string
+	origin Origin
+}
+
+func (s *bindSetting[T]) from() Origin {
+	return s.origin
+}
+
+func (s *bindSetting[T]) resolve(env, dotenv Source) error {
+	if s.origin == OriginFlag || s.env == "" {
+		return nil
+	}
+
+	for _, src := range []struct {
+		src    Source
+		origin Origin
+	}{
+		{src: env, origin: OriginEnv},
+		{src: dotenv, origin: OriginDotenv},
+	} {
+		if src.src == nil {
+			continue
+		}
+
+		v, ok, err := lookupVar(src.src, s.env)
+		if err != nil {
+			return err
+		}
+
+		if !ok {
+			continue
+		}
+
+		val, err := decode(s.env, v, s.fn)
+		if err != nil {
+			return err
+		}
+
+		*s.p = val
+		s.origin = src.origin
+
+		return nil
+	}
+
+	return nil
+}
+
+// IsBoolFlag implements the interface used by the flag package for boolean
+// flags.
+func (s *bindSetting[T]) IsBoolFlag() bool {
+	return reflect.TypeOf((*T)(nil)).Elem().Kind() == reflect.Bool
+}
+
+// Set implements flag.Value.
+func (s *bindSetting[T]) Set(v string) error {
+	val, err := s.fn(v)
+	if err != nil {
+		return err //nolint:wrapcheck
+	}
+
+	*s.p = val
+	s.origin = OriginFlag
+
+	return nil
+}
+
+// String implements flag.Value.
+func (s *bindSetting[T]) String() string {
+	if s == nil || s.p == nil {
+		return ""
+	}
+
+	return fmt.Sprint(*s.p)
+}
@@ -0,0 +1,107 @@
+// Copyright 2026 Miguel Angel Rivera Notararigo. All rights reserved.
+// This source code was released under the MIT license.
+
+package env_test
+
+import (
+	"errors"
+	"flag"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"go.ntrrg.dev/ntgo/os/env"
+)
+
+func TestBinder(t *testing.T) {
+	t.Parallel()
+
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+	fs.SetOutput(io.Discard)
+
+	b := env.NewBinder(
+		fs,
+		env.WithBindEnv(env.Vars{"PORT": "8081", "HOST": "env.example"}),
+		env.WithBindDotenv(env.Vars{
+			"HOST":    "dotenv.example",
+			"TIMEOUT": "1m",
+			"LEVEL":   "debug",
+		}),
+	)
+
+	port := env.Bind(b, "port", "PORT", 8080, env.Int, "listening port")
+	host := env.Bind(b, "host", "HOST", "localhost", env.String, "host")
+	wait := env.Bind(b, "timeout", "TIMEOUT", time.Second, env.Duration, "")
+	debug := env.Bind(b, "debug", "DEBUG", false, env.Bool, "debug mode")
+	workers := env.Bind(b, "workers", "", 4, env.Int, "workers")
+	level := env.Bind(b, "", "LEVEL", "info", env.String, "")
+
+	if *port != 8080 {
+		t.Errorf("invalid default value. got: %v, want: %v", *port, 8080)
+	}
+
+	if err := b.Parse([]string{"-host", "flag.example", "-debug"}); err != nil {
+		t.Fatalf("cannot parse settings: %v", err)
+	}
+
+	cases := []struct {
+		name   string
+		got    any
+		want   any
+		origin env.Origin
+	}{
+		{name: "port", got: *port, want: 8081, origin: env.OriginEnv},
+		{
+			name:   "host",
+			got:    *host,
+			want:   "flag.example",
+			origin: env.OriginFlag,
+		},
+		{
+			name:   "TIMEOUT",
+			got:    *wait,
+			want:   time.Minute,
+			origin: env.OriginDotenv,
+		},
+		{name: "debug", got: *debug, want: true, origin: env.OriginFlag},
+		{name: "workers", got: *workers, want: 4, origin: env.OriginDefault},
+		{name: "LEVEL", got: *level, want: "debug", origin: env.OriginDotenv},
+		{name: "missing", origin: env.OriginUnknown},
+	}
+
+	for _, c := range cases {
+		if c.got != c.want {
+			t.Errorf("[%s] invalid value. got: %v, want: %v", c.name, c.got, c.want)
+		}
+
+		if o := b.Origin(c.name); o != c.origin {
+			t.Errorf("[%s] invalid origin. got: %v, want: %v", c.name, o, c.origin)
+		}
+	}
+
+	usage := fs.Lookup("port").Usage
+	if !strings.Contains(usage, "$PORT") {
+		t.Errorf("variable is missing from usage: %q", usage)
+	}
+}
+
+func TestBinder_errors(t *testing.T) {
+	t.Parallel()
+
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+	fs.SetOutput(io.Discard)
+
+	b := env.NewBinder(fs, env.WithBindEnv(env.Vars{"PORT": "x"}))
+	env.Bind(b, "port", "PORT", 8080, env.Int, "listening port")
+	env.Bind(b, "workers", "WORKERS", 4, env.Int, "workers")
+
+	if err := b.Parse([]string{"-workers", "x"}); err == nil {
+		t.Error("invalid flag was parsed")
+	}
+
+	err := b.Parse(nil)
+	if !errors.Is(err, env.ErrCannotDecode) {
+		t.Errorf("invalid error. got: %v, want: %v", err, env.ErrCannotDecode)
+	}
+}
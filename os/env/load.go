@@ -0,0 +1,355 @@
+// Copyright 2026 Miguel Angel Rivera Notararigo. All rights reserved.
+// This source code was released under the MIT license.
+
+package env
+
+import (
+	"encoding"
+	"errors"
+	"net/url"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	nterrors "go.ntrrg.dev/ntgo/errors"
+)
+
+// Load populates the struct pointed by v with environment variables. Fields
+// are configured with struct tags:
+//
+//   - env: variable name. Fields without this tag are ignored, unless they are
+//     structs.
+//   - default: value used when the variable is absent.
+//   - required: if "true", the variable must be defined.
+//   - prefix: prepended to the variable names of a nested struct fields.
+//   - sep: separator for slice elements and map entries. Default is ",".
+//   - secret: if "true", values are redacted from errors. Secret fields are
+//     always redacted.
+//   - desc: variable description, used by Describe.
+//
+// Supported field types are strings, booleans, numbers, time.Duration,
+// url.URL, types implementing encoding.TextUnmarshaler (e.g. ByteSize,
+// net.IP, netip.Prefix, time.Time with RFC 3339 format), and slices, maps and
+// pointers of any of them. Map entries use the "key=value" syntax.
+//
+//	type Config struct {
+//		Port  int           `env:"PORT" default:"8080"`
+//		Hosts []string      `env:"HOSTS" required:"true"`
+//		Wait  time.Duration `env:"WAIT" default:"5s"`
+//
+//		DB struct {
+//			URL string `env:"URL"` // Reads DB_URL.
+//		} `prefix:"DB_"`
+//	}
+//
+// All the variables are loaded before returning, problems are reported as an
+// error group (see go.ntrrg.dev/ntgo/errors.Group) of ErrUndefined and
+// ErrCannotDecode errors wrapping a VarError. Invalid specifications (e.g.
+// unsupported field types) are reported as ErrInvalidSpec.
+func Load(v any) error {
+	return LoadFrom(Process, v)
+}
+
+// VarError records the environment variable involved in a failed operation.
+type VarError struct {
+	Name string
+	Err  error
+}
+
+// Error implements the error interface.
+func (e *VarError) Error() string {
+	if e.Err == nil {
+		return e.Name
+	}
+
+	return e.Name + ": " + e.Err.Error()
+}
+
+// Unwrap allows to use functions from errors package over VarError.
+func (e *VarError) Unwrap() error {
+	return e.Err
+}
+
+func load(v any, src Source) error {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Pointer || rv.IsNil() ||
+		rv.Elem().Kind() != reflect.Struct {
+		return ErrInvalidSpec.Wrap(errors.New("a struct pointer is required"))
+	}
+
+	l := loader{src: src}
+	if err := l.loadStruct(rv.Elem(), ""); err != nil {
+		return err
+	}
+
+	if len(l.errs) > 0 {
+		return nterrors.Group(l.errs...)
+	}
+
+	return nil
+}
+
+type loader struct {
+	src  Source
+	errs []error
+}
+
+func (l *loader) loadStruct(rv reflect.Value, prefix string) error {
+	t := rv.Type()
+
+	for i := 0; i < t.NumField(); i++ {
+		sf := t.Field(i)
+		if !sf.IsExported() {
+			continue
+		}
+
+		fv := rv.Field(i)
+
+		name, ok := sf.Tag.Lookup("env")
+		if name == "-" {
+			continue
+		}
+
+		if !ok {
+			if !isNested(sf.Type) {
+				continue
+			}
+
+			if fv.Kind() == reflect.Pointer {
+				if fv.IsNil() {
+					fv.Set(reflect.New(sf.Type.Elem()))
+				}
+
+				fv = fv.Elem()
+			}
+
+			if err := l.loadStruct(fv, prefix+sf.Tag.Get("prefix")); err != nil {
+				return err
+			}
+
+			continue
+		}
+
+		if err := l.loadField(fv, sf, prefix+name); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (l *loader) loadField(
+	fv reflect.Value,
+	sf reflect.StructField,
+	k string,
+) error {
+	if !isSupported(sf.Type) {
+		return ErrInvalidSpec.Wrap(&VarError{
+			Name: k,
+			Err:  errors.New("unsupported type " + sf.Type.String()),
+		})
+	}
+
+	secret := sf.Tag.Get("secret") == "true" || isSecret(sf.Type)
+
+	v, ok, err := lookupVar(l.src, k)
+	if err != nil {
+		l.errs = append(l.errs, err)
+		return nil
+	}
+
+	if !ok {
+		if sf.Tag.Get("required") == "true" {
+			l.errs = append(l.errs, ErrUndefined.Wrap(&VarError{Name: k}))
+			return nil
+		}
+
+		if v, ok = sf.Tag.Lookup("default"); !ok {
+			return nil
+		}
+	}
+
+	sep := ","
+	if s, ok := sf.Tag.Lookup("sep"); ok {
+		sep = s
+	}
+
+	if err := decodeValue(fv, v, sep); err != nil {
+		if secret {
+			err = redact(err)
+		}
+
+		l.errs = append(l.errs, ErrCannotDecode.Wrap(&VarError{Name: k, Err: err}))
+	}
+
+	return nil
+}
+
+var (
+	durationType  = reflect.TypeOf(time.Duration(0))
+	urlType       = reflect.TypeOf(url.URL{})
+	unmarshalType = reflect.TypeOf((*encoding.TextUnmarshaler)(nil)).Elem()
+)
+
+// decodeValue decodes s into rv. sep is used for splitting slices and maps.
+func decodeValue(rv reflect.Value, s, sep string) error {
+	if rv.CanAddr() && rv.Addr().Type().Implements(unmarshalType) {
+		u, _ := rv.Addr().Interface().(encoding.TextUnmarshaler)
+		return u.UnmarshalText([]byte(s)) //nolint:wrapcheck
+	}
+
+	switch rv.Type() {
+	case durationType:
+		d, err := Duration(s)
+		if err != nil {
+			return err
+		}
+
+		rv.SetInt(int64(d))
+
+		return nil
+	case urlType:
+		u, err := URL(s)
+		if err != nil {
+			return err
+		}
+
+		rv.Set(reflect.ValueOf(*u))
+
+		return nil
+	}
+
+	switch rv.Kind() { //nolint:exhaustive
+	case reflect.String:
+		rv.SetString(s)
+	case reflect.Bool:
+		b, err := strconv.ParseBool(s)
+		if err != nil {
+			return err //nolint:wrapcheck
+		}
+
+		rv.SetBool(b)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32,
+		reflect.Int64:
+		n, err := strconv.ParseInt(s, 0, rv.Type().Bits())
+		if err != nil {
+			return err //nolint:wrapcheck
+		}
+
+		rv.SetInt(n)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32,
+		reflect.Uint64, reflect.Uintptr:
+		n, err := strconv.ParseUint(s, 0, rv.Type().Bits())
+		if err != nil {
+			return err //nolint:wrapcheck
+		}
+
+		rv.SetUint(n)
+	case reflect.Float32, reflect.Float64:
+		n, err := strconv.ParseFloat(s, rv.Type().Bits())
+		if err != nil {
+			return err //nolint:wrapcheck
+		}
+
+		rv.SetFloat(n)
+	case reflect.Pointer:
+		p := reflect.New(rv.Type().Elem())
+		if err := decodeValue(p.Elem(), s, sep); err != nil {
+			return err
+		}
+
+		rv.Set(p)
+	case reflect.Slice:
+		return decodeSlice(rv, s, sep)
+	case reflect.Map:
+		return decodeMap(rv, s, sep)
+	}
+
+	return nil
+}
+
+func decodeSlice(rv reflect.Value, s, sep string) error {
+	if s == "" {
+		rv.Set(reflect.MakeSlice(rv.Type(), 0, 0))
+		return nil
+	}
+
+	items := strings.Split(s, sep)
+	sl := reflect.MakeSlice(rv.Type(), len(items), len(items))
+
+	for i, item := range items {
+		err := decodeValue(sl.Index(i), strings.TrimSpace(item), sep)
+		if err != nil {
+			return err
+		}
+	}
+
+	rv.Set(sl)
+
+	return nil
+}
+
+func decodeMap(rv reflect.Value, s, sep string) error {
+	m := reflect.MakeMap(rv.Type())
+
+	for _, entry := range strings.Split(s, sep) {
+		if strings.TrimSpace(entry) == "" {
+			continue
+		}
+
+		ks, vs, ok := strings.Cut(entry, "=")
+		if !ok {
+			return errors.New("invalid map entry '" + entry + "'")
+		}
+
+		k := reflect.New(rv.Type().Key()).Elem()
+		if err := decodeValue(k, strings.TrimSpace(ks), sep); err != nil {
+			return err
+		}
+
+		v := reflect.New(rv.Type().Elem()).Elem()
+		if err := decodeValue(v, strings.TrimSpace(vs), sep); err != nil {
+			return err
+		}
+
+		m.SetMapIndex(k, v)
+	}
+
+	rv.Set(m)
+
+	return nil
+}
+
+// isNested reports if t is a struct (or a pointer to a struct) whose fields
+// should be loaded.
+func isNested(t reflect.Type) bool {
+	if t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+
+	return t.Kind() == reflect.Struct && t != urlType &&
+		!reflect.PointerTo(t).Implements(unmarshalType)
+}
+
+// isSupported reports if values of type t can be decoded.
+func isSupported(t reflect.Type) bool {
+	if t == urlType || reflect.PointerTo(t).Implements(unmarshalType) {
+		return true
+	}
+
+	switch t.Kind() { //nolint:exhaustive
+	case reflect.String, reflect.Bool,
+		reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32,
+		reflect.Uint64, reflect.Uintptr,
+		reflect.Float32, reflect.Float64:
+		return true
+	case reflect.Pointer, reflect.Slice:
+		return isSupported(t.Elem())
+	case reflect.Map:
+		return isSupported(t.Key()) && isSupported(t.Elem())
+	}
+
+	return false
+}
@@ -0,0 +1,143 @@
+// Copyright 2026 Miguel Angel Rivera Notararigo. All rights reserved.
+// This source code was released under the MIT license.
+
+package env_test
+
+import (
+	"errors"
+	"net"
+	"net/url"
+	"reflect"
+	"testing"
+	"time"
+
+	nterrors "go.ntrrg.dev/ntgo/errors"
+	"go.ntrrg.dev/ntgo/os/env"
+)
+
+type testConfig struct {
+	Port    int               `env:"PORT" default:"8080"`
+	Hosts   []string          `env:"HOSTS" required:"true"`
+	Wait    time.Duration     `env:"WAIT" default:"5s"`
+	Debug   bool              `env:"DEBUG"`
+	Ratio   float32           `env:"RATIO"`
+	Labels  map[string]int    `env:"LABELS"`
+	Ports   []uint16          `env:"PORTS" sep:";"`
+	IP      net.IP            `env:"IP"`
+	Timeout *time.Duration    `env:"TIMEOUT"`
+	Ignored string            `env:"-"`
+	Extra   map[string]string `env:"EXTRA"`
+	Size    env.ByteSize      `env:"SIZE" default:"1KiB"`
+	API     *url.URL          `env:"API"`
+
+	DB struct {
+		URL  string `env:"URL" required:"true"`
+		Pool int    `env:"POOL" default:"4"`
+	} `prefix:"DB_"`
+
+	Cache *struct {
+		Size int `env:"SIZE"`
+	} `prefix:"CACHE_"`
+
+	unexported string `env:"UNEXPORTED"` //nolint:unused
+}
+
+func TestLoad(t *testing.T) {
+	for k, v := range map[string]string{
+		"X_TEST_LOAD_HOSTS":      "a.example, b.example",
+		"X_TEST_LOAD_DEBUG":      "true",
+		"X_TEST_LOAD_RATIO":      "0.5",
+		"X_TEST_LOAD_LABELS":     "a=1,b=2",
+		"X_TEST_LOAD_PORTS":      "80;443",
+		"X_TEST_LOAD_IP":         "127.0.0.1",
+		"X_TEST_LOAD_TIMEOUT":    "1m",
+		"X_TEST_LOAD_DB_URL":     "postgres://localhost",
+		"X_TEST_LOAD_CACHE_SIZE": "10",
+		"X_TEST_LOAD_API":        "https://api.example",
+	} {
+		t.Setenv(k, v)
+	}
+
+	var got struct {
+		Config testConfig `prefix:"X_TEST_LOAD_"`
+	}
+
+	if err := env.Load(&got); err != nil {
+		t.Fatalf("cannot load configuration: %v", err)
+	}
+
+	timeout := time.Minute
+
+	want := testConfig{
+		Port:    8080,
+		Hosts:   []string{"a.example", "b.example"},
+		Wait:    5 * time.Second,
+		Debug:   true,
+		Ratio:   0.5,
+		Labels:  map[string]int{"a": 1, "b": 2},
+		Ports:   []uint16{80, 443},
+		IP:      net.ParseIP("127.0.0.1"),
+		Timeout: &timeout,
+		Size:    env.KiB,
+		API:     &url.URL{Scheme: "https", Host: "api.example"},
+	}
+
+	want.DB.URL = "postgres://localhost"
+	want.DB.Pool = 4
+	want.Cache = &struct {
+		Size int `env:"SIZE"`
+	}{Size: 10}
+
+	if !reflect.DeepEqual(got.Config, want) {
+		t.Errorf("invalid configuration. got: %+v, want: %+v", got.Config, want)
+	}
+}
+
+func TestLoad_errors(t *testing.T) {
+	t.Setenv("X_TEST_LOAD_ERRORS_PORT", "http")
+	t.Setenv("X_TEST_LOAD_ERRORS_LABELS", "a")
+
+	var cfg struct {
+		Config testConfig `prefix:"X_TEST_LOAD_ERRORS_"`
+	}
+
+	err := env.Load(&cfg)
+
+	errs := nterrors.Split(err)
+	if len(errs) != 4 {
+		t.Fatalf("invalid errors. got: %v, want: 4 errors", err)
+	}
+
+	for _, target := range []error{env.ErrUndefined, env.ErrCannotDecode} {
+		if !errors.Is(err, target) {
+			t.Errorf("%v is not reported: %v", target, err)
+		}
+	}
+
+	var verr *env.VarError
+	if !errors.As(errs[0], &verr) || verr.Name != "X_TEST_LOAD_ERRORS_PORT" {
+		t.Errorf("invalid variable error: %v", errs[0])
+	}
+}
+
+func TestLoad_invalidSpec(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		label string
+		v     any
+	}{
+		{label: "Nil", v: nil},
+		{label: "Not a pointer", v: testConfig{}},
+		{label: "Unsupported type", v: &struct {
+			C chan int `env:"X_TEST_LOAD_CHAN"`
+		}{}},
+	}
+
+	for _, c := range cases {
+		err := env.Load(c.v)
+		if !errors.Is(err, env.ErrInvalidSpec) {
+			t.Errorf("[%s] invalid error: %v", c.label, err)
+		}
+	}
+}
@@ -0,0 +1,307 @@
+// Copyright 2026 Miguel Angel Rivera Notararigo. All rights reserved.
+// This source code was released under the MIT license.
+
+package env
+
+import (
+	"context"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"reflect"
+	"sync"
+	"sync/atomic"
+
+	ntos "go.ntrrg.dev/ntgo/os"
+)
+
+// Reloader holds a configuration of type T, loaded as described by Load, that
+// can be reloaded while the program runs. T must be a struct type.
+//
+// Reloads are triggered by calling Reload, by signals (SIGHUP by default on
+// Unix systems) and by changes in the dotenv files given with WithDotenvFiles
+// and the secret files used by the configuration (see "Secret files" in
+// package documentation). If a reload fails, the previous configuration is
+// kept.
+//
+//	r, err := env.NewReloader[Config](ctx, env.WithDotenvFiles(".env"))
+//	if err != nil {
+//		return err
+//	}
+//
+//	r.Subscribe(func(old, cfg *Config) {
+//		log.Printf("log level changed to %s", cfg.LogLevel)
+//	})
+//
+//	cfg := r.Value()
+type Reloader[T any] struct {
+	cur atomic.Pointer[T]
+	o   reloadOptions
+
+	mu     sync.Mutex // Serializes reloads.
+	subsMu sync.Mutex
+	subs   map[int]func(old, cfg *T)
+	nextID int
+}
+
+// NewReloader loads the configuration and starts watching for reload
+// triggers until ctx is done. Errors from the initial load are returned as
+// reported by LoadFrom.
+func NewReloader[T any](
+	ctx context.Context,
+	opts ...ReloadOption,
+) (*Reloader[T], error) {
+	o := reloadOptions{src: Process, signals: defaultReloadSignals}
+
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	r := &Reloader[T]{o: o, subs: map[int]func(old, cfg *T){}}
+
+	cfg, err := r.load()
+	if err != nil {
+		return nil, err
+	}
+
+	r.cur.Store(cfg)
+
+	ctx, cancel := context.WithCancel(ctx)
+
+	events, err := r.watch(ctx)
+	if err != nil {
+		cancel()
+		return nil, err
+	}
+
+	sigs := make(chan os.Signal, 1)
+	if len(o.signals) > 0 {
+		signal.Notify(sigs, o.signals...)
+	}
+
+	go func() {
+		defer cancel()
+		defer signal.Stop(sigs)
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-sigs:
+			case <-events:
+			}
+
+			if err := r.Reload(); err != nil && o.errFn != nil {
+				o.errFn(err)
+			}
+		}
+	}()
+
+	return r, nil
+}
+
+// Value returns the current configuration. It must not be modified.
+func (r *Reloader[T]) Value() *T {
+	return r.cur.Load()
+}
+
+// Reload loads the configuration again. If it changed, it replaces the
+// current configuration and subscribers are notified. Failures are reported
+// as ErrReload errors and the current configuration is kept.
+func (r *Reloader[T]) Reload() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	cfg, err := r.load()
+	if err != nil {
+		return ErrReload.Wrap(err)
+	}
+
+	old := r.cur.Load()
+	if reflect.DeepEqual(old, cfg) {
+		return nil
+	}
+
+	r.cur.Store(cfg)
+
+	r.subsMu.Lock()
+
+	subs := make([]func(old, cfg *T), 0, len(r.subs))
+	for _, fn := range r.subs {
+		subs = append(subs, fn)
+	}
+
+	r.subsMu.Unlock()
+
+	for _, fn := range subs {
+		fn(old, cfg)
+	}
+
+	return nil
+}
+
+// Subscribe registers fn for being called with the previous and the new
+// configuration after every successful reload that changes it. Calls are
+// made synchronously by the reloading goroutine. The returned function
+// unregisters fn.
+func (r *Reloader[T]) Subscribe(fn func(old, cfg *T)) (cancel func()) {
+	r.subsMu.Lock()
+	defer r.subsMu.Unlock()
+
+	id := r.nextID
+	r.nextID++
+	r.subs[id] = fn
+
+	return func() {
+		r.subsMu.Lock()
+		defer r.subsMu.Unlock()
+
+		delete(r.subs, id)
+	}
+}
+
+func (r *Reloader[T]) load() (*T, error) {
+	src, err := r.source()
+	if err != nil {
+		return nil, err
+	}
+
+	cfg := new(T)
+
+	if err := LoadFrom(src, cfg); err != nil {
+		return nil, err
+	}
+
+	return cfg, nil
+}
+
+// source reads the dotenv files and layers them under the reloader source.
+func (r *Reloader[T]) source() (Source, error) {
+	srcs := []Source{r.o.src}
+
+	for _, path := range r.o.dotenvs {
+		d, err := ReadDotenv(path)
+		if err != nil {
+			return nil, err
+		}
+
+		srcs = append(srcs, d)
+	}
+
+	return Layered(srcs...), nil
+}
+
+// watch watches the directories of the dotenv files and the secret files
+// used by the configuration. Events are merged into the returned channel.
+func (r *Reloader[T]) watch(ctx context.Context) (<-chan struct{}, error) {
+	events := make(chan struct{}, 1)
+
+	files, err := r.files()
+	if err != nil {
+		return nil, err
+	}
+
+	dirs := map[string]bool{}
+
+	for _, f := range files {
+		dir := filepath.Dir(f)
+		if dirs[dir] {
+			continue
+		}
+
+		dirs[dir] = true
+
+		w, err := ntos.Watch(ctx, dir)
+		if err != nil {
+			return nil, ErrReload.Wrap(err)
+		}
+
+		go func() {
+			for range w.Events {
+				select {
+				case events <- struct{}{}:
+				default:
+				}
+			}
+		}()
+
+		go func() {
+			for err := range w.Errors {
+				if r.o.errFn != nil {
+					r.o.errFn(ErrReload.Wrap(err))
+				}
+			}
+		}()
+	}
+
+	return events, nil
+}
+
+// files returns the dotenv files and the secret files used by the
+// configuration.
+func (r *Reloader[T]) files() ([]string, error) {
+	files := append([]string(nil), r.o.dotenvs...)
+
+	specs, err := Describe((*T)(nil))
+	if err != nil {
+		return nil, err
+	}
+
+	src, err := r.source()
+	if err != nil {
+		return nil, err
+	}
+
+	for _, s := range specs {
+		if path, ok := src.Lookup(s.Name + FileSuffix); ok {
+			files = append(files, path)
+		}
+	}
+
+	return files, nil
+}
+
+// ReloadOption configures reloaders.
+type ReloadOption func(*reloadOptions)
+
+// WithDotenvFiles reads the dotenv files at paths on every load. The process
+// environment (or the source given with WithReloadSource) takes precedence,
+// so files should be given from the highest to the lowest priority. Changes
+// in these files trigger reloads.
+func WithDotenvFiles(paths ...string) ReloadOption {
+	return func(o *reloadOptions) {
+		o.dotenvs = append(o.dotenvs, paths...)
+	}
+}
+
+// WithReloadErrors sets a function for reporting errors from reloads
+// triggered by signals or file changes.
+func WithReloadErrors(fn func(error)) ReloadOption {
+	return func(o *reloadOptions) {
+		o.errFn = fn
+	}
+}
+
+// WithReloadSignals sets the signals that trigger reloads. Default is SIGHUP
+// on Unix systems and no signals on other platforms, calling it without
+// arguments disables signals.
+func WithReloadSignals(sigs ...os.Signal) ReloadOption {
+	return func(o *reloadOptions) {
+		o.signals = sigs
+	}
+}
+
+// WithReloadSource sets the source for loading the configuration. Default is
+// Process.
+func WithReloadSource(src Source) ReloadOption {
+	return func(o *reloadOptions) {
+		o.src = src
+	}
+}
+
+type reloadOptions struct {
+	dotenvs []string
+	errFn   func(error)
+	signals []os.Signal
+	src     Source
+}
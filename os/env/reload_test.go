@@ -0,0 +1,154 @@
+// Copyright 2026 Miguel Angel Rivera Notararigo. All rights reserved.
+// This source code was released under the MIT license.
+
+package env_test
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"go.ntrrg.dev/ntgo/os/env"
+)
+
+type reloadConfig struct {
+	Level    string             `env:"LEVEL" default:"info"`
+	Workers  int                `env:"WORKERS" required:"true"`
+	Password env.Secret[string] `env:"PASSWORD"`
+}
+
+func TestReloader(t *testing.T) {
+	t.Parallel()
+
+	dir, err := os.MkdirTemp("", "ntgo-os-env-reload")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	defer os.RemoveAll(dir)
+
+	dotenv := filepath.Join(dir, ".env")
+	secret := filepath.Join(dir, "password")
+
+	// Files are replaced atomically, so reloads never read partial writes.
+	write := func(path, data string) {
+		t.Helper()
+
+		tmp := path + ".tmp"
+		if err := os.WriteFile(tmp, []byte(data), 0o600); err != nil {
+			t.Fatal(err)
+		}
+
+		if err := os.Rename(tmp, path); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	write(dotenv, "WORKERS=4\n")
+	write(secret, "s3cr3t")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	errs := make(chan error, 16)
+
+	r, err := env.NewReloader[reloadConfig](
+		ctx,
+		env.WithDotenvFiles(dotenv),
+		env.WithReloadErrors(func(err error) { errs <- err }),
+		env.WithReloadSignals(),
+		env.WithReloadSource(env.Vars{"PASSWORD_FILE": secret}),
+	)
+
+	if err != nil {
+		t.Fatalf("cannot load configuration: %v", err)
+	}
+
+	if cfg := r.Value(); cfg.Level != "info" || cfg.Workers != 4 ||
+		cfg.Password.Value() != "s3cr3t" {
+		t.Fatalf("invalid configuration: %+v", cfg)
+	}
+
+	type change struct{ old, cfg *reloadConfig }
+
+	changes := make(chan change, 16)
+	r.Subscribe(func(old, cfg *reloadConfig) { changes <- change{old, cfg} })
+
+	wait := func(label string) *reloadConfig {
+		t.Helper()
+
+		select {
+		case c := <-changes:
+			if c.old == c.cfg {
+				t.Errorf("[%s] old and new configurations are the same", label)
+			}
+
+			if r.Value() != c.cfg {
+				t.Errorf("[%s] current configuration was not replaced", label)
+			}
+
+			return c.cfg
+		case err := <-errs:
+			t.Fatalf("[%s] cannot reload configuration: %v", label, err)
+		case <-time.After(5 * time.Second):
+			t.Fatalf("[%s] configuration was not reloaded", label)
+		}
+
+		return nil
+	}
+
+	write(dotenv, "WORKERS=8\nLEVEL=debug\n")
+
+	if cfg := wait("Dotenv"); cfg.Workers != 8 || cfg.Level != "debug" {
+		t.Errorf("[Dotenv] invalid configuration: %+v", cfg)
+	}
+
+	write(secret, "n3w")
+
+	if cfg := wait("Secret"); cfg.Password.Value() != "n3w" {
+		t.Errorf("[Secret] invalid configuration: %+v", cfg)
+	}
+
+	prev := r.Value()
+	write(dotenv, "WORKERS=x\n")
+
+	select {
+	case err := <-errs:
+		if !errors.Is(err, env.ErrReload) {
+			t.Errorf("invalid error. got: %v, want: %v", err, env.ErrReload)
+		}
+
+		if !errors.Is(err, env.ErrCannotDecode) {
+			t.Errorf("invalid error. got: %v, want: %v", err, env.ErrCannotDecode)
+		}
+	case c := <-changes:
+		t.Fatalf("invalid configuration was loaded: %+v", c.cfg)
+	case <-time.After(5 * time.Second):
+		t.Fatal("configuration was not reloaded")
+	}
+
+	if r.Value() != prev {
+		t.Errorf("previous configuration was not kept")
+	}
+
+	if err := r.Reload(); err == nil {
+		t.Errorf("invalid configuration was loaded")
+	}
+}
+
+func TestReloader_invalid(t *testing.T) {
+	t.Parallel()
+
+	_, err := env.NewReloader[reloadConfig](
+		context.Background(),
+		env.WithReloadSignals(),
+		env.WithReloadSource(env.Vars{}),
+	)
+
+	if !errors.Is(err, env.ErrUndefined) {
+		t.Errorf("invalid error. got: %v, want: %v", err, env.ErrUndefined)
+	}
+}
@@ -0,0 +1,13 @@
+// Copyright 2026 Miguel Angel Rivera Notararigo. All rights reserved.
+// This source code was released under the MIT license.
+
+//go:build !js && !plan9 && !windows
+
+package env
+
+import (
+	"os"
+	"syscall"
+)
+
+var defaultReloadSignals = []os.Signal{syscall.SIGHUP}
@@ -0,0 +1,161 @@
+// Copyright 2026 Miguel Angel Rivera Notararigo. All rights reserved.
+// This source code was released under the MIT license.
+
+package env
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"reflect"
+	"strings"
+)
+
+// FileSuffix is appended to variable names for finding their secret file
+// variable.
+const FileSuffix = "_FILE"
+
+// MaxSecretFileSize is the maximum size of secret files.
+const MaxSecretFileSize = 64 << 10
+
+// Redacted replaces secret values.
+const Redacted = "[REDACTED]"
+
+// Secret holds a sensitive value. Formatting, marshaling or printing a secret
+// gives Redacted instead of its value.
+type Secret[T any] struct {
+	value T
+}
+
+// NewSecret creates a secret holding v.
+func NewSecret[T any](v T) Secret[T] {
+	return Secret[T]{value: v}
+}
+
+// AsSecret returns a decoder for secrets that uses fn for decoding their
+// value. Decoding errors are redacted.
+func AsSecret[T any](fn Decoder[T]) Decoder[Secret[T]] {
+	return func(v string) (Secret[T], error) {
+		val, err := fn(v)
+		if err != nil {
+			return Secret[T]{}, redact(err)
+		}
+
+		return NewSecret(val), nil
+	}
+}
+
+// Value returns the secret value.
+func (s Secret[T]) Value() T {
+	return s.value
+}
+
+// Format implements fmt.Formatter.
+func (s Secret[T]) Format(f fmt.State, verb rune) {
+	io.WriteString(f, Redacted) //nolint:errcheck
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (s Secret[T]) MarshalText() ([]byte, error) {
+	return []byte(Redacted), nil
+}
+
+// String implements fmt.Stringer.
+func (s Secret[T]) String() string {
+	return Redacted
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler, so secrets can be used
+// with Load. The value is decoded as described by Load.
+func (s *Secret[T]) UnmarshalText(text []byte) error {
+	rv := reflect.ValueOf(&s.value).Elem()
+	if !isSupported(rv.Type()) {
+		return errors.New("unsupported type " + rv.Type().String())
+	}
+
+	if err := decodeValue(rv, string(text), ","); err != nil {
+		return redact(err)
+	}
+
+	return nil
+}
+
+func (s Secret[T]) secret() {}
+
+// isSecret reports if t is a Secret.
+func isSecret(t reflect.Type) bool {
+	return t.Implements(secretType)
+}
+
+var secretType = reflect.TypeOf((*interface{ secret() })(nil)).Elem()
+
+// lookupVar retrieves the value of the variable k from src. If the variable
+// k+FileSuffix is defined, the value is read from the file it points to.
+func lookupVar(src Source, k string) (string, bool, error) {
+	v, ok := src.Lookup(k)
+
+	fk := k + FileSuffix
+
+	path, fok := src.Lookup(fk)
+	if !fok {
+		return v, ok, nil
+	}
+
+	if ok {
+		return "", false, ErrConflict.Wrap(&VarError{
+			Name: k,
+			Err:  errors.New("'" + fk + "' is also defined"),
+		})
+	}
+
+	v, err := readSecretFile(path)
+	if err != nil {
+		return "", false, ErrSecretFile.Wrap(&VarError{Name: fk, Err: err})
+	}
+
+	return v, true, nil
+}
+
+// readSecretFile reads the file at path, removing surrounding spaces.
+func readSecretFile(path string) (string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return "", err //nolint:wrapcheck
+	}
+
+	defer f.Close()
+
+	data, err := io.ReadAll(io.LimitReader(f, MaxSecretFileSize+1))
+	if err != nil {
+		return "", err //nolint:wrapcheck
+	}
+
+	if len(data) > MaxSecretFileSize {
+		return "", errors.New("'" + path + "' is too big")
+	}
+
+	return strings.TrimSpace(string(data)), nil
+}
+
+// redactedError hides the message of errors that may contain secret values.
+type redactedError struct {
+	err error
+}
+
+func redact(err error) error {
+	var rerr *redactedError
+	if errors.As(err, &rerr) {
+		return err
+	}
+
+	return &redactedError{err: err}
+}
+
+func (e *redactedError) Error() string {
+	return "invalid value " + Redacted
+}
+
+func (e *redactedError) Unwrap() error {
+	return e.err
+}
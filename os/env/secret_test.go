@@ -0,0 +1,132 @@
+// Copyright 2026 Miguel Angel Rivera Notararigo. All rights reserved.
+// This source code was released under the MIT license.
+
+package env_test
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"go.ntrrg.dev/ntgo/os/env"
+)
+
+func TestLookup_secretFile(t *testing.T) {
+	t.Parallel()
+
+	dir, err := os.MkdirTemp("", "ntgo-os-env-secret")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "db")
+	if err := os.WriteFile(path, []byte("  s3cr3t\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	big := filepath.Join(dir, "big")
+	data := strings.Repeat("x", env.MaxSecretFileSize+1)
+
+	if err := os.WriteFile(big, []byte(data), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	src := env.Vars{
+		"DB_PASSWORD_FILE": path,
+		"BOTH":             "value",
+		"BOTH_FILE":        path,
+		"MISSING_FILE":     filepath.Join(dir, "missing"),
+		"BIG_FILE":         big,
+	}
+
+	v, err := env.LookupFrom(src, "DB_PASSWORD", env.AsSecret(env.String))
+	if err != nil {
+		t.Fatalf("cannot read secret file: %v", err)
+	}
+
+	if v.Value() != "s3cr3t" {
+		t.Errorf("invalid value. got: %q, want: %q", v.Value(), "s3cr3t")
+	}
+
+	cases := []struct {
+		key  string
+		want error
+	}{
+		{key: "BOTH", want: env.ErrConflict},
+		{key: "MISSING", want: env.ErrSecretFile},
+		{key: "BIG", want: env.ErrSecretFile},
+	}
+
+	for _, c := range cases {
+		_, err := env.GetFrom(src, c.key, env.String)
+		if !errors.Is(err, c.want) {
+			t.Errorf("[%s] invalid error. got: %v, want: %v", c.key, err, c.want)
+		}
+	}
+}
+
+func TestSecret(t *testing.T) {
+	t.Parallel()
+
+	s := env.NewSecret("s3cr3t")
+
+	out := []string{
+		fmt.Sprint(s),
+		fmt.Sprintf("%s %q %+v %#v", s, s, s, s),
+		fmt.Sprintf("%v", struct{ S env.Secret[string] }{s}),
+		s.String(),
+	}
+
+	data, err := json.Marshal(map[string]any{"password": s})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	out = append(out, string(data))
+
+	for _, o := range out {
+		if strings.Contains(o, "s3cr3t") || !strings.Contains(o, env.Redacted) {
+			t.Errorf("secret value was not redacted: %s", o)
+		}
+	}
+
+	_, err = env.AsSecret(env.Int)("s3cr3t")
+	if err == nil {
+		t.Fatal("invalid secret was decoded")
+	}
+
+	if strings.Contains(err.Error(), "s3cr3t") {
+		t.Errorf("secret value was not redacted: %v", err)
+	}
+}
+
+func TestLoad_secrets(t *testing.T) {
+	t.Parallel()
+
+	var cfg struct {
+		Password env.Secret[string] `env:"PASSWORD"`
+		Token    env.Secret[int]    `env:"TOKEN"`
+		Key      int                `env:"KEY" secret:"true"`
+	}
+
+	src := env.Vars{"PASSWORD": "s3cr3t", "TOKEN": "s3cr3t", "KEY": "s3cr3t"}
+
+	err := env.LoadFrom(src, &cfg)
+	if err == nil {
+		t.Fatal("invalid secrets were decoded")
+	}
+
+	if strings.Contains(err.Error(), "s3cr3t") {
+		t.Errorf("secret value was not redacted: %v", err)
+	}
+
+	if cfg.Password.Value() != "s3cr3t" {
+		t.Errorf("invalid value. got: %q, want: %q", cfg.Password.Value(), "s3cr3t")
+	}
+}
@@ -0,0 +1,131 @@
+// Copyright 2026 Miguel Angel Rivera Notararigo. All rights reserved.
+// This source code was released under the MIT license.
+
+package env
+
+import (
+	"errors"
+	"syscall"
+)
+
+// Source provides environment variable values.
+type Source interface {
+	// Lookup retrieves the value of the variable k, the returned boolean is
+	// false if the variable is absent.
+	Lookup(k string) (string, bool)
+}
+
+// Process is the process environment.
+var Process Source = processSource{}
+
+type processSource struct{}
+
+func (processSource) Lookup(k string) (string, bool) {
+	return syscall.Getenv(k)
+}
+
+// Vars is a Source backed by a map, useful for tests.
+type Vars map[string]string
+
+// Lookup implements Source.
+func (s Vars) Lookup(k string) (string, bool) {
+	v, ok := s[k]
+	return v, ok
+}
+
+// Layered returns a Source that looks up variables in srcs in order, the
+// first source defining a variable wins.
+//
+//	// Process environment over .env file.
+//	src := Layered(Process, dotenv)
+func Layered(srcs ...Source) Source {
+	return layeredSource(srcs)
+}
+
+type layeredSource []Source
+
+func (s layeredSource) Lookup(k string) (string, bool) {
+	for _, src := range s {
+		if v, ok := src.Lookup(k); ok {
+			return v, true
+		}
+	}
+
+	return "", false
+}
+
+// Prefixed returns a view of src where variable names are prefixed with
+// prefix, e.g. looking up "PORT" in Prefixed("APP_", src) retrieves "APP_PORT"
+// from src.
+func Prefixed(prefix string, src Source) Source {
+	return prefixedSource{prefix: prefix, src: src}
+}
+
+type prefixedSource struct {
+	prefix string
+	src    Source
+}
+
+func (s prefixedSource) Lookup(k string) (string, bool) {
+	return s.src.Lookup(s.prefix + k)
+}
+
+// GetFrom is like Get, but retrieves the variable from src.
+func GetFrom[T any](src Source, k string, fn Decoder[T]) (T, error) {
+	v, _, err := lookupVar(src, k)
+	if err != nil {
+		var zero T
+		return zero, err
+	}
+
+	return decode(k, v, fn)
+}
+
+// GetOrFrom is like GetOr, but retrieves the variable from src.
+func GetOrFrom[T any](src Source, k string, v T, fn Decoder[T]) (T, error) {
+	_v, _, err := lookupVar(src, k)
+	if err != nil {
+		return v, err
+	}
+
+	if _v == "" {
+		return v, nil
+	}
+
+	return decode(k, _v, fn)
+}
+
+// LookupFrom is like Lookup, but retrieves the variable from src.
+func LookupFrom[T any](src Source, k string, fn Decoder[T]) (T, error) {
+	var v T
+
+	_v, ok, err := lookupVar(src, k)
+	if err != nil {
+		return v, err
+	}
+
+	if !ok {
+		return v, ErrUndefined.Wrap(errors.New("'" + k + "' not found"))
+	}
+
+	return decode(k, _v, fn)
+}
+
+// LookupOrFrom is like LookupOr, but retrieves the variable from src.
+func LookupOrFrom[T any](src Source, k string, v T, fn Decoder[T]) (T, error) {
+	_v, ok, err := lookupVar(src, k)
+	if err != nil {
+		return v, err
+	}
+
+	if !ok {
+		return v, nil
+	}
+
+	return decode(k, _v, fn)
+}
+
+// LoadFrom is like Load, but retrieves the variables from src.
+func LoadFrom(src Source, v any) error {
+	return load(v, src)
+}
@@ -0,0 +1,101 @@
+// Copyright 2026 Miguel Angel Rivera Notararigo. All rights reserved.
+// This source code was released under the MIT license.
+
+package env_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"go.ntrrg.dev/ntgo/os/env"
+)
+
+func TestSources(t *testing.T) {
+	t.Parallel()
+
+	dotenv, err := env.ParseDotenv(strings.NewReader("A=dotenv\nB=dotenv\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	vars := env.Vars{"A": "vars", "APP_A": "prefixed", "EMPTY": ""}
+	src := env.Layered(vars, dotenv)
+
+	cases := []struct {
+		label string
+		src   env.Source
+		key   string
+		want  string
+		found bool
+	}{
+		{label: "Vars", src: vars, key: "A", want: "vars", found: true},
+		{label: "Empty", src: vars, key: "EMPTY", want: "", found: true},
+		{label: "Missing", src: vars, key: "B"},
+		{label: "Layered", src: src, key: "A", want: "vars", found: true},
+		{label: "Layered fallback", src: src, key: "B", want: "dotenv", found: true},
+		{label: "Layered missing", src: src, key: "C"},
+		{
+			label: "Prefixed",
+			src:   env.Prefixed("APP_", src),
+			key:   "A",
+			want:  "prefixed",
+			found: true,
+		},
+		{label: "Prefixed missing", src: env.Prefixed("APP_", src), key: "B"},
+	}
+
+	for _, c := range cases {
+		got, ok := c.src.Lookup(c.key)
+		if got != c.want || ok != c.found {
+			t.Errorf(
+				"[%s] invalid value. got: %q (%v), want: %q (%v)",
+				c.label, got, ok, c.want, c.found,
+			)
+		}
+	}
+}
+
+func TestLookupFrom(t *testing.T) {
+	t.Parallel()
+
+	src := env.Vars{"PORT": "8080", "EMPTY": "", "INVALID": "x"}
+
+	if v, err := env.GetOrFrom(src, "EMPTY", 80, env.Int); err != nil || v != 80 {
+		t.Errorf("invalid value. got: %v (%v), want: %v", v, err, 80)
+	}
+
+	v, err := env.LookupOrFrom(src, "MISSING", 80, env.Int)
+	if err != nil || v != 80 {
+		t.Errorf("invalid value. got: %v (%v), want: %v", v, err, 80)
+	}
+
+	_, err = env.LookupFrom(src, "MISSING", env.Int)
+	if !errors.Is(err, env.ErrUndefined) {
+		t.Errorf("invalid error. got: %v, want: %v", err, env.ErrUndefined)
+	}
+
+	_, err = env.GetFrom(src, "INVALID", env.Int)
+	if !errors.Is(err, env.ErrCannotDecode) {
+		t.Errorf("invalid error. got: %v, want: %v", err, env.ErrCannotDecode)
+	}
+}
+
+func TestLoadFrom(t *testing.T) {
+	t.Parallel()
+
+	var cfg struct {
+		Port int    `env:"PORT"`
+		Host string `env:"HOST" default:"localhost"`
+	}
+
+	src := env.Prefixed("APP_", env.Vars{"APP_PORT": "8080"})
+
+	if err := env.LoadFrom(src, &cfg); err != nil {
+		t.Fatalf("cannot load configuration: %v", err)
+	}
+
+	if cfg.Port != 8080 || cfg.Host != "localhost" {
+		t.Errorf("invalid configuration: %+v", cfg)
+	}
+}
@@ -0,0 +1,288 @@
+// Copyright 2026 Miguel Angel Rivera Notararigo. All rights reserved.
+// This source code was released under the MIT license.
+
+package env
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"reflect"
+	"strings"
+	"text/tabwriter"
+)
+
+// Spec describes an environment variable.
+type Spec struct {
+	Name string
+
+	// Type is a human readable name of the variable type.
+	Type string
+
+	Default    string
+	HasDefault bool
+
+	Required bool
+	Secret   bool
+
+	// Desc is the variable description, from the desc struct tag.
+	Desc string
+}
+
+// Specs is a list of environment variable specifications.
+type Specs []Spec
+
+// Describe returns the specifications of the variables that would be loaded
+// into v by Load, in field order. v must be a struct or a pointer to a
+// struct, its value is not used.
+//
+// Besides the struct tags used by Load, descriptions are taken from the desc
+// tag.
+//
+//	type Config struct {
+//		Port int `env:"PORT" default:"8080" desc:"Listening port."`
+//	}
+//
+//	specs, err := env.Describe(Config{})
+func Describe(v any) (Specs, error) {
+	t := reflect.TypeOf(v)
+	if t != nil && t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+
+	if t == nil || t.Kind() != reflect.Struct {
+		return nil, ErrInvalidSpec.Wrap(errors.New("a struct is required"))
+	}
+
+	var specs Specs
+
+	if err := describeStruct(&specs, t, ""); err != nil {
+		return nil, err
+	}
+
+	return specs, nil
+}
+
+func describeStruct(specs *Specs, t reflect.Type, prefix string) error {
+	for i := 0; i < t.NumField(); i++ {
+		sf := t.Field(i)
+		if !sf.IsExported() {
+			continue
+		}
+
+		name, ok := sf.Tag.Lookup("env")
+		if name == "-" {
+			continue
+		}
+
+		if !ok {
+			if !isNested(sf.Type) {
+				continue
+			}
+
+			ft := sf.Type
+			if ft.Kind() == reflect.Pointer {
+				ft = ft.Elem()
+			}
+
+			err := describeStruct(specs, ft, prefix+sf.Tag.Get("prefix"))
+			if err != nil {
+				return err
+			}
+
+			continue
+		}
+
+		k := prefix + name
+
+		if !isSupported(sf.Type) {
+			return ErrInvalidSpec.Wrap(&VarError{
+				Name: k,
+				Err:  errors.New("unsupported type " + sf.Type.String()),
+			})
+		}
+
+		def, hasDef := sf.Tag.Lookup("default")
+
+		*specs = append(*specs, Spec{
+			Name:       k,
+			Type:       typeName(sf.Type),
+			Default:    def,
+			HasDefault: hasDef,
+			Required:   sf.Tag.Get("required") == "true",
+			Secret:     sf.Tag.Get("secret") == "true" || isSecret(sf.Type),
+			Desc:       sf.Tag.Get("desc"),
+		})
+	}
+
+	return nil
+}
+
+// WriteUsage writes specs to w as a table, suitable for --help messages.
+//
+//	NAME  TYPE     DEFAULT  DESCRIPTION
+//	PORT  integer  8080     Listening port.
+//	HOST  string   -        Server host. (required)
+func (specs Specs) WriteUsage(w io.Writer) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+
+	fmt.Fprintln(tw, "NAME\tTYPE\tDEFAULT\tDESCRIPTION")
+
+	for _, s := range specs {
+		def := "-"
+		if s.HasDefault {
+			def = s.defaultValue()
+		}
+
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\n", s.Name, s.Type, def, s.notes())
+	}
+
+	return tw.Flush() //nolint:wrapcheck
+}
+
+// WriteMarkdown writes specs to w as a Markdown table.
+func (specs Specs) WriteMarkdown(w io.Writer) error {
+	var b strings.Builder
+
+	b.WriteString("| Name | Type | Default | Description |\n")
+	b.WriteString("| ---- | ---- | ------- | ----------- |\n")
+
+	for _, s := range specs {
+		def := ""
+		if s.HasDefault {
+			def = "`" + s.defaultValue() + "`"
+		}
+
+		fmt.Fprintf(
+			&b, "| `%s` | %s | %s | %s |\n",
+			s.Name, s.Type, def, mdEscape(s.notes()),
+		)
+	}
+
+	_, err := io.WriteString(w, b.String())
+
+	return err //nolint:wrapcheck
+}
+
+// WriteDotenv writes a sample dotenv file with specs to w. Descriptions are
+// written as comments, required variables are assigned to their default
+// value or left empty, and optional variables are commented out.
+//
+//	# Listening port.
+//	# PORT=8080
+//
+//	# Server host. (required)
+//	HOST=
+func (specs Specs) WriteDotenv(w io.Writer) error {
+	var b strings.Builder
+
+	for i, s := range specs {
+		if i > 0 {
+			b.WriteByte('\n')
+		}
+
+		if notes := s.notes(); notes != "" {
+			for _, line := range strings.Split(notes, "\n") {
+				b.WriteString(strings.TrimRight("# "+line, " ") + "\n")
+			}
+		}
+
+		if !s.Required {
+			b.WriteString("# ")
+		}
+
+		b.WriteString(s.Name + "=" + dotenvQuote(s.defaultValue()) + "\n")
+	}
+
+	_, err := io.WriteString(w, b.String())
+
+	return err //nolint:wrapcheck
+}
+
+// defaultValue returns the default value of s, redacted for secrets.
+func (s Spec) defaultValue() string {
+	if s.Secret && s.Default != "" {
+		return Redacted
+	}
+
+	return s.Default
+}
+
+// notes returns the description of s, with annotations.
+func (s Spec) notes() string {
+	var notes []string
+
+	if s.Required {
+		notes = append(notes, "required")
+	}
+
+	if s.Secret {
+		notes = append(notes, "secret")
+	}
+
+	if len(notes) == 0 {
+		return s.Desc
+	}
+
+	n := "(" + strings.Join(notes, ", ") + ")"
+	if s.Desc == "" {
+		return n
+	}
+
+	return s.Desc + " " + n
+}
+
+// typeName returns a human readable name for t.
+func typeName(t reflect.Type) string {
+	if t.Kind() == reflect.Pointer {
+		return typeName(t.Elem())
+	}
+
+	switch {
+	case t == durationType:
+		return "duration"
+	case t == urlType:
+		return "URL"
+	case isSecret(t):
+		return typeName(t.Field(0).Type)
+	case t.Name() != "" && t.PkgPath() != "":
+		return t.String()
+	}
+
+	switch t.Kind() { //nolint:exhaustive
+	case reflect.Bool:
+		return "boolean"
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32,
+		reflect.Int64:
+		return "integer"
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32,
+		reflect.Uint64, reflect.Uintptr:
+		return "unsigned integer"
+	case reflect.Float32, reflect.Float64:
+		return "number"
+	case reflect.Slice:
+		return "list of " + typeName(t.Elem())
+	case reflect.Map:
+		return "map of " + typeName(t.Key()) + " to " + typeName(t.Elem())
+	}
+
+	return t.String()
+}
+
+func mdEscape(s string) string {
+	r := strings.NewReplacer("|", `\|`, "\n", "<br>")
+	return r.Replace(s)
+}
+
+// dotenvQuote quotes v if it can't be used as an unquoted dotenv value.
+func dotenvQuote(v string) string {
+	if !strings.ContainsAny(v, " \t\n\r#'\"\\$") {
+		return v
+	}
+
+	r := strings.NewReplacer(
+		`\`, `\\`, `"`, `\"`, "$", `\$`, "\n", `\n`, "\r", `\r`, "\t", `\t`,
+	)
+
+	return `"` + r.Replace(v) + `"`
+}
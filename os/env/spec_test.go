@@ -0,0 +1,167 @@
+// Copyright 2026 Miguel Angel Rivera Notararigo. All rights reserved.
+// This source code was released under the MIT license.
+
+package env_test
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"go.ntrrg.dev/ntgo/os/env"
+)
+
+type specConfig struct {
+	Port     int                `env:"PORT" default:"8080" desc:"Listening port."`
+	Host     string             `env:"HOST" required:"true" desc:"Server host."`
+	Wait     time.Duration      `env:"WAIT" default:"5s"`
+	Tags     []string           `env:"TAGS" desc:"Tags | labels."`
+	Password env.Secret[string] `env:"PASSWORD"`
+	Greeting string             `env:"GREETING" default:"hello world"`
+
+	DB *struct {
+		URL string `env:"URL" required:"true" secret:"true"`
+	} `prefix:"DB_"`
+}
+
+func TestDescribe(t *testing.T) {
+	t.Parallel()
+
+	specs, err := env.Describe((*specConfig)(nil))
+	if err != nil {
+		t.Fatalf("cannot describe configuration: %v", err)
+	}
+
+	want := env.Specs{
+		{
+			Name:       "PORT",
+			Type:       "integer",
+			Default:    "8080",
+			HasDefault: true,
+			Desc:       "Listening port.",
+		},
+		{Name: "HOST", Type: "string", Required: true, Desc: "Server host."},
+		{Name: "WAIT", Type: "duration", Default: "5s", HasDefault: true},
+		{Name: "TAGS", Type: "list of string", Desc: "Tags | labels."},
+		{Name: "PASSWORD", Type: "string", Secret: true},
+		{
+			Name:       "GREETING",
+			Type:       "string",
+			Default:    "hello world",
+			HasDefault: true,
+		},
+		{Name: "DB_URL", Type: "string", Required: true, Secret: true},
+	}
+
+	if !reflect.DeepEqual(specs, want) {
+		t.Errorf("invalid specs. got: %+v, want: %+v", specs, want)
+	}
+
+	_, err = env.Describe(struct {
+		C chan int `env:"C"`
+	}{})
+
+	if !errors.Is(err, env.ErrInvalidSpec) {
+		t.Errorf("invalid error. got: %v, want: %v", err, env.ErrInvalidSpec)
+	}
+
+	if _, err := env.Describe(1); !errors.Is(err, env.ErrInvalidSpec) {
+		t.Errorf("invalid error. got: %v, want: %v", err, env.ErrInvalidSpec)
+	}
+}
+
+func TestSpecs_WriteUsage(t *testing.T) {
+	t.Parallel()
+
+	specs := env.Specs{
+		{Name: "PORT", Type: "integer", Default: "8080", HasDefault: true},
+		{Name: "HOST", Type: "string", Required: true, Desc: "Server host."},
+	}
+
+	var b strings.Builder
+
+	if err := specs.WriteUsage(&b); err != nil {
+		t.Fatal(err)
+	}
+
+	want := "" +
+		"NAME  TYPE     DEFAULT  DESCRIPTION\n" +
+		"PORT  integer  8080     \n" +
+		"HOST  string   -        Server host. (required)\n"
+
+	if got := b.String(); got != want {
+		t.Errorf("invalid usage. got:\n%s\nwant:\n%s", got, want)
+	}
+}
+
+func TestSpecs_WriteMarkdown(t *testing.T) {
+	t.Parallel()
+
+	specs := env.Specs{
+		{Name: "PORT", Type: "integer", Default: "8080", HasDefault: true},
+		{Name: "TAGS", Type: "list of string", Desc: "Tags | labels."},
+		{
+			Name:       "TOKEN",
+			Type:       "string",
+			Default:    "s3cr3t",
+			HasDefault: true,
+			Secret:     true,
+		},
+	}
+
+	var b strings.Builder
+
+	if err := specs.WriteMarkdown(&b); err != nil {
+		t.Fatal(err)
+	}
+
+	want := "" +
+		"| Name | Type | Default | Description |\n" +
+		"| ---- | ---- | ------- | ----------- |\n" +
+		"| `PORT` | integer | `8080` |  |\n" +
+		"| `TAGS` | list of string |  | Tags \\| labels. |\n" +
+		"| `TOKEN` | string | `[REDACTED]` | (secret) |\n"
+
+	if got := b.String(); got != want {
+		t.Errorf("invalid markdown. got:\n%s\nwant:\n%s", got, want)
+	}
+}
+
+func TestSpecs_WriteDotenv(t *testing.T) {
+	t.Parallel()
+
+	specs, err := env.Describe(specConfig{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var b strings.Builder
+
+	if err := specs.WriteDotenv(&b); err != nil {
+		t.Fatal(err)
+	}
+
+	want := "" +
+		"# Listening port.\n# PORT=8080\n\n" +
+		"# Server host. (required)\nHOST=\n\n" +
+		"# WAIT=5s\n\n" +
+		"# Tags | labels.\n# TAGS=\n\n" +
+		"# (secret)\n# PASSWORD=\n\n" +
+		"# GREETING=\"hello world\"\n\n" +
+		"# (required, secret)\nDB_URL=\n"
+
+	if got := b.String(); got != want {
+		t.Errorf("invalid dotenv file. got:\n%s\nwant:\n%s", got, want)
+	}
+
+	d, err := env.ParseDotenv(strings.NewReader(want))
+	if err != nil {
+		t.Fatalf("cannot parse generated dotenv file: %v", err)
+	}
+
+	if v, _ := d.Lookup("HOST"); v != "" {
+		t.Errorf("invalid value. got: %q, want: %q", v, "")
+	}
+}
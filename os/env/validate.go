@@ -0,0 +1,181 @@
+// Copyright 2026 Miguel Angel Rivera Notararigo. All rights reserved.
+// This source code was released under the MIT license.
+
+package env
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"reflect"
+	"regexp"
+	"strings"
+)
+
+// Validator checks decoded values.
+type Validator[T any] func(T) error
+
+// Validate returns a decoder that decodes values with fn and checks them with
+// validators, in order. Validation failures are reported as ErrInvalid by
+// functions retrieving variables.
+//
+//	port, err := env.Get("PORT", env.Validate(env.Int, env.Port[int]()))
+//
+// Values are included in error messages, unless T is a Secret, in which case
+// they are replaced by Redacted.
+//
+//	env.Validate(env.AsSecret(env.String), env.Check(long, "too short"))
+func Validate[T any](fn Decoder[T], validators ...Validator[T]) Decoder[T] {
+	secret := isSecret(reflect.TypeOf((*T)(nil)).Elem())
+
+	return func(v string) (T, error) {
+		val, err := fn(v)
+		if err != nil {
+			return val, err
+		}
+
+		if secret {
+			v = Redacted
+		}
+
+		for _, check := range validators {
+			if err := check(val); err != nil {
+				return val, &ValidationError{Value: v, Err: err}
+			}
+		}
+
+		return val, nil
+	}
+}
+
+// ValidationError records a value that failed validation.
+type ValidationError struct {
+	Value string
+	Err   error
+}
+
+// Error implements the error interface.
+func (e *ValidationError) Error() string {
+	return "'" + e.Value + "': " + e.Err.Error()
+}
+
+// Unwrap allows to use functions from errors package over ValidationError.
+func (e *ValidationError) Unwrap() error {
+	return e.Err
+}
+
+// Check creates a validator from fn, msg is used as error message when fn
+// returns false.
+func Check[T any](fn func(T) bool, msg string) Validator[T] {
+	return func(v T) error {
+		if !fn(v) {
+			return errors.New(msg)
+		}
+
+		return nil
+	}
+}
+
+// Min checks that values are greater than or equal to n.
+func Min[T ordered](n T) Validator[T] {
+	return func(v T) error {
+		if v < n {
+			return fmt.Errorf("must be greater than or equal to %v", n)
+		}
+
+		return nil
+	}
+}
+
+// Max checks that values are less than or equal to n.
+func Max[T ordered](n T) Validator[T] {
+	return func(v T) error {
+		if v > n {
+			return fmt.Errorf("must be less than or equal to %v", n)
+		}
+
+		return nil
+	}
+}
+
+// OneOf checks that values are one of values.
+func OneOf[T comparable](values ...T) Validator[T] {
+	return func(v T) error {
+		for _, val := range values {
+			if v == val {
+				return nil
+			}
+		}
+
+		s := make([]string, 0, len(values))
+		for _, val := range values {
+			s = append(s, fmt.Sprint(val))
+		}
+
+		return errors.New("must be one of: " + strings.Join(s, ", "))
+	}
+}
+
+// Match checks that values match re.
+func Match(re *regexp.Regexp) Validator[string] {
+	return func(v string) error {
+		if !re.MatchString(v) {
+			return errors.New("must match " + re.String())
+		}
+
+		return nil
+	}
+}
+
+// NonEmpty checks that values are not empty. Strings, slices and maps must
+// have elements, other types must not be their zero value.
+func NonEmpty[T any]() Validator[T] {
+	return func(v T) error {
+		rv := reflect.ValueOf(&v).Elem()
+
+		switch rv.Kind() { //nolint:exhaustive
+		case reflect.String, reflect.Slice, reflect.Map:
+			if rv.Len() > 0 {
+				return nil
+			}
+		default:
+			if !rv.IsZero() {
+				return nil
+			}
+		}
+
+		return errors.New("must not be empty")
+	}
+}
+
+// Port checks that values are valid TCP/UDP port numbers (1-65535).
+func Port[T integer]() Validator[T] {
+	return func(v T) error {
+		if v < 1 || uint64(v) > 65535 {
+			return errors.New("must be a port number between 1 and 65535")
+		}
+
+		return nil
+	}
+}
+
+// Scheme checks that URLs use one of schemes.
+func Scheme(schemes ...string) Validator[*url.URL] {
+	return func(u *url.URL) error {
+		for _, s := range schemes {
+			if strings.EqualFold(u.Scheme, s) {
+				return nil
+			}
+		}
+
+		return errors.New("scheme must be one of: " + strings.Join(schemes, ", "))
+	}
+}
+
+type integer interface {
+	signed | unsigned
+}
+
+type ordered interface {
+	integer | ~float32 | ~float64 | ~string
+}
@@ -0,0 +1,165 @@
+// Copyright 2026 Miguel Angel Rivera Notararigo. All rights reserved.
+// This source code was released under the MIT license.
+
+package env_test
+
+import (
+	"errors"
+	"net/url"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+
+	"go.ntrrg.dev/ntgo/os/env"
+)
+
+func TestValidate(t *testing.T) {
+	t.Parallel()
+
+	src := env.Vars{
+		"PORT":     "8080",
+		"BIG_PORT": "70000",
+		"TIMEOUT":  "90s",
+		"LEVEL":    "trace",
+		"NAME":     "my-app",
+		"BAD_NAME": "My App",
+		"EMPTY":    "",
+		"URL":      "ftp://example.com",
+		"EVEN":     "3",
+		"INVALID":  "x",
+		"PASSWORD": "",
+
+		"SHORT_PASSWORD": "hunter2",
+	}
+
+	name := regexp.MustCompile(`^[a-z-]+$`)
+	even := env.Check(func(n int) bool { return n%2 == 0 }, "must be even")
+	port := env.Validate(env.Int, env.Port[int]())
+
+	get := func(k string, fn any) error {
+		var err error
+
+		switch fn := fn.(type) {
+		case env.Decoder[int]:
+			_, err = env.GetFrom(src, k, fn)
+		case env.Decoder[string]:
+			_, err = env.GetFrom(src, k, fn)
+		case env.Decoder[time.Duration]:
+			_, err = env.GetFrom(src, k, fn)
+		case env.Decoder[*url.URL]:
+			_, err = env.GetFrom(src, k, fn)
+		}
+
+		return err
+	}
+
+	cases := []struct {
+		key  string
+		fn   any
+		fail bool
+	}{
+		{key: "PORT", fn: port},
+		{key: "BIG_PORT", fn: port, fail: true},
+		{
+			key: "TIMEOUT",
+			fn: env.Validate(
+				env.Duration,
+				env.Min(time.Second),
+				env.Max(time.Minute),
+			),
+			fail: true,
+		},
+		{
+			key:  "LEVEL",
+			fn:   env.Validate(env.String, env.OneOf("debug", "info")),
+			fail: true,
+		},
+		{key: "NAME", fn: env.Validate(env.String, env.Match(name))},
+		{
+			key:  "BAD_NAME",
+			fn:   env.Validate(env.String, env.Match(name)),
+			fail: true,
+		},
+		{
+			key:  "EMPTY",
+			fn:   env.Validate(env.String, env.NonEmpty[string]()),
+			fail: true,
+		},
+		{
+			key:  "URL",
+			fn:   env.Validate(env.URL, env.Scheme("http", "https")),
+			fail: true,
+		},
+		{key: "EVEN", fn: env.Validate(env.Int, even), fail: true},
+	}
+
+	for _, c := range cases {
+		err := get(c.key, c.fn)
+
+		if !c.fail {
+			if err != nil {
+				t.Errorf("[%s] validation failed: %v", c.key, err)
+			}
+
+			continue
+		}
+
+		if !errors.Is(err, env.ErrInvalid) {
+			t.Errorf(
+				"[%s] invalid error. got: %v, want: %v",
+				c.key, err, env.ErrInvalid,
+			)
+
+			continue
+		}
+
+		var verr *env.VarError
+		if !errors.As(err, &verr) || verr.Name != c.key {
+			t.Errorf("[%s] variable name is missing: %v", c.key, err)
+		}
+
+		if !strings.Contains(err.Error(), "'"+src[c.key]+"'") {
+			t.Errorf("[%s] value is missing: %v", c.key, err)
+		}
+	}
+
+	_, err := env.GetFrom(src, "INVALID", port)
+	if !errors.Is(err, env.ErrCannotDecode) {
+		t.Errorf("invalid error. got: %v, want: %v", err, env.ErrCannotDecode)
+	}
+
+	_, err = env.GetFrom(src, "PASSWORD", env.AsSecret(env.Validate(
+		env.String,
+		env.NonEmpty[string](),
+	)))
+
+	if !errors.Is(err, env.ErrInvalid) {
+		t.Errorf("invalid error. got: %v, want: %v", err, env.ErrInvalid)
+	}
+
+	var verr *env.ValidationError
+	if !errors.As(err, &verr) {
+		t.Errorf("invalid error. got: %v, want: %T", err, verr)
+	}
+
+	if !strings.Contains(err.Error(), env.Redacted) {
+		t.Errorf("secret value was not redacted: %v", err)
+	}
+
+	long := func(s env.Secret[string]) bool { return len(s.Value()) >= 8 }
+
+	_, err = env.GetFrom(src, "SHORT_PASSWORD", env.Validate(
+		env.AsSecret(env.String),
+		env.Check(long, "too short"),
+	))
+
+	if !errors.Is(err, env.ErrInvalid) {
+		t.Errorf("invalid error. got: %v, want: %v", err, env.ErrInvalid)
+	}
+
+	if s := err.Error(); strings.Contains(s, src["SHORT_PASSWORD"]) ||
+		!strings.Contains(s, env.Redacted) {
+		t.Errorf("secret value was not redacted: %v", err)
+	}
+}
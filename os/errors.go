@@ -9,3 +9,56 @@ import (
 
 // Err is the main error group for this package.
 var Err = ntgo.Err.New("os", "os package errors")
+
+var (
+	ErrNotSupported = Err.New(
+		"not-supported",
+		"operation not supported on this platform",
+	)
+
+	ErrInvalidManifest = Err.New("manifest", "invalid manifest")
+)
+
+// Copy errors.
+var (
+	ErrCopy = Err.New("copy", "cannot copy")
+
+	ErrCopyAttr     = ErrCopy.New("attr", "cannot set file attributes")
+	ErrCopyChecksum = ErrCopy.New("checksum", "checksum mismatch")
+	ErrCopyClone    = ErrCopy.New("clone", "cannot clone file")
+	ErrCopyMkdir    = ErrCopy.New("mkdir", "cannot create directory")
+	ErrCopyNotDir   = ErrCopy.New("not-dir", "source is not a directory")
+	ErrCopyOpenDst  = ErrCopy.New("open-dst", "cannot open destination file")
+	ErrCopyOpenSrc  = ErrCopy.New("open-src", "cannot open source file")
+	ErrCopyPath     = ErrCopy.New("path", "cannot resolve path")
+	ErrCopyRemove   = ErrCopy.New("remove", "cannot remove file")
+	ErrCopyRename   = ErrCopy.New("rename", "cannot rename file")
+	ErrCopySymlink  = ErrCopy.New("symlink", "cannot copy symbolic link")
+	ErrCopyStat     = ErrCopy.New("stat", "cannot stat file")
+	ErrCopyWrite    = ErrCopy.New("write", "cannot copy data")
+
+	ErrCopySelf = ErrCopy.New(
+		"self-copy",
+		"cannot copy a directory into itself",
+	)
+
+	// Archive errors.
+	ErrCopyArchive    = ErrCopy.New("archive", "invalid archive")
+	ErrCopyUnsafePath = ErrCopyArchive.New("unsafe-path", "unsafe entry path")
+)
+
+// Watch errors.
+var (
+	ErrWatch         = Err.New("watch", "cannot watch files")
+	ErrWatchNotDir   = ErrWatch.New("not-dir", "watched path is not a directory")
+	ErrWatchOverflow = ErrWatch.New("overflow", "too many events, some were lost")
+)
+
+// Lock errors.
+var (
+	ErrLock       = Err.New("lock", "cannot lock file")
+	ErrLocked     = ErrLock.New("locked", "lock is held by another process")
+	ErrInvalidPID = ErrLock.New("invalid-pid", "invalid PID in lock file")
+)
+
+var ErrWorkspace = Err.New("workspace", "workspace failure")
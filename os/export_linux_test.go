@@ -0,0 +1,25 @@
+// Copyright 2026 Miguel Angel Rivera Notararigo. All rights reserved.
+// This source code was released under the MIT license.
+
+package os
+
+import (
+	"os"
+)
+
+// CopyMethod is the method used for copying data, see fileCopier.
+type CopyMethod = copyMethod
+
+// Data copy methods, from the preferred to the last fallback.
+const (
+	CopyFileRange = copyFileRange
+	CopySendfile  = copySendfile
+	CopyUserspace = copyUserspace
+)
+
+// CopyData copies from into to, starting with the copy method m.
+func CopyData(to, from *os.File, m CopyMethod) error {
+	c := &fileCopier{to: to, from: from, method: m}
+
+	return c.copy()
+}
@@ -0,0 +1,54 @@
+// Copyright 2026 Miguel Angel Rivera Notararigo. All rights reserved.
+// This source code was released under the MIT license.
+
+package os
+
+import (
+	"io/fs"
+	"path"
+)
+
+// Filter reports if a file should be processed. path is slash-separated and
+// relative to the directory being processed.
+type Filter func(path string, fi fs.FileInfo) bool
+
+// Include creates a Filter that accepts files matching any of the given
+// patterns. Patterns use the path.Match syntax and are matched against the
+// full relative path and the file name. Directories are always accepted, so
+// their content can be matched.
+func Include(patterns ...string) Filter {
+	return func(p string, fi fs.FileInfo) bool {
+		return fi.IsDir() || matchAny(p, patterns)
+	}
+}
+
+// Exclude creates a Filter that rejects files and directories matching any of
+// the given patterns. Patterns work as in Include.
+func Exclude(patterns ...string) Filter {
+	return func(p string, fi fs.FileInfo) bool {
+		return !matchAny(p, patterns)
+	}
+}
+
+// And creates a Filter that accepts files accepted by both f and other.
+func (f Filter) And(other Filter) Filter {
+	return func(p string, fi fs.FileInfo) bool {
+		return f(p, fi) && other(p, fi)
+	}
+}
+
+func matchAny(p string, patterns []string) bool {
+	name := path.Base(p)
+
+	for _, pattern := range patterns {
+		if ok, _ := path.Match(pattern, p); ok {
+			return true
+		}
+
+		if ok, _ := path.Match(pattern, name); ok {
+			return true
+		}
+	}
+
+	return false
+}
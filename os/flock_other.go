@@ -0,0 +1,21 @@
+// Copyright 2026 Miguel Angel Rivera Notararigo. All rights reserved.
+// This source code was released under the MIT license.
+
+//go:build !darwin && !dragonfly && !freebsd && !linux && !netbsd && !openbsd
+
+package os
+
+import (
+	"errors"
+	"os"
+)
+
+var errWouldBlock = errors.New("operation would block")
+
+func flock(f *os.File, shared bool) error {
+	return ErrNotSupported
+}
+
+func funlock(f *os.File) error {
+	return ErrNotSupported
+}
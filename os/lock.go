@@ -0,0 +1,403 @@
+// Copyright 2026 Miguel Angel Rivera Notararigo. All rights reserved.
+// This source code was released under the MIT license.
+
+package os
+
+import (
+	"context"
+	"errors"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Lock acquires an advisory lock on the file at path, creating it if needed.
+// It waits until the lock is acquired or ctx is done, in which case an
+// ErrLocked error wrapping the context error is returned. Locks are exclusive
+// by default, see WithShared.
+//
+// Advisory locks only coordinate processes that use them, they don't prevent
+// other processes from accessing the file. Locks are released when the
+// process exits.
+func Lock(
+	ctx context.Context,
+	path string,
+	opts ...LockOption,
+) (*FileLock, error) {
+	o := newLockOptions(opts)
+
+	l, err := openLock(path, o)
+	if err != nil {
+		return nil, err
+	}
+
+	if o.timeout > 0 {
+		var cancel context.CancelFunc
+
+		ctx, cancel = context.WithTimeout(ctx, o.timeout)
+		defer cancel()
+	}
+
+	ticker := time.NewTicker(o.retry)
+	defer ticker.Stop()
+
+	for {
+		err := l.tryLock()
+		if err == nil {
+			return l, nil
+		}
+
+		if !errors.Is(err, ErrLocked) {
+			l.f.Close()
+			return nil, err
+		}
+
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			l.f.Close()
+			return nil, ErrLocked.Wrap(ctx.Err())
+		}
+	}
+}
+
+// TryLock is like Lock, but returns an ErrLocked error immediately if the lock
+// is held by another process.
+func TryLock(path string, opts ...LockOption) (*FileLock, error) {
+	l, err := openLock(path, newLockOptions(opts))
+	if err != nil {
+		return nil, err
+	}
+
+	if err := l.tryLock(); err != nil {
+		l.f.Close()
+		return nil, err
+	}
+
+	return l, nil
+}
+
+// FileLock is an advisory lock on a file.
+type FileLock struct {
+	f      *os.File
+	shared bool
+}
+
+// File returns the locked file.
+func (l *FileLock) File() *os.File {
+	return l.f
+}
+
+// Unlock releases the lock and closes the file. The file is not removed.
+func (l *FileLock) Unlock() error {
+	if err := funlock(l.f); err != nil {
+		l.f.Close()
+		return ErrLock.Wrap(err)
+	}
+
+	if err := l.f.Close(); err != nil {
+		return ErrLock.Wrap(err)
+	}
+
+	return nil
+}
+
+func openLock(path string, o lockOptions) (*FileLock, error) {
+	f, err := os.OpenFile(path, os.O_RDWR|os.O_CREATE, 0o644)
+	if err != nil {
+		return nil, ErrLock.Wrap(err)
+	}
+
+	return &FileLock{f: f, shared: o.shared}, nil
+}
+
+func (l *FileLock) tryLock() error {
+	err := flock(l.f, l.shared)
+	if err == nil {
+		return nil
+	}
+
+	if errors.Is(err, errWouldBlock) {
+		return ErrLocked
+	}
+
+	return ErrLock.Wrap(err)
+}
+
+// LockOption customizes a file lock.
+type LockOption func(*lockOptions)
+
+// WithLockRetry sets how often Lock tries to acquire the lock. Default is
+// 50ms.
+func WithLockRetry(d time.Duration) LockOption {
+	return func(o *lockOptions) {
+		if d > 0 {
+			o.retry = d
+		}
+	}
+}
+
+// WithLockTimeout sets how long Lock waits for acquiring the lock.
+func WithLockTimeout(d time.Duration) LockOption {
+	return func(o *lockOptions) {
+		o.timeout = d
+	}
+}
+
+// WithShared acquires a shared lock, which may be held by multiple processes
+// at the same time, but not while an exclusive lock is held.
+func WithShared() LockOption {
+	return func(o *lockOptions) {
+		o.shared = true
+	}
+}
+
+type lockOptions struct {
+	retry   time.Duration
+	shared  bool
+	timeout time.Duration
+}
+
+func newLockOptions(opts []LockOption) lockOptions {
+	o := lockOptions{retry: 50 * time.Millisecond}
+
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	return o
+}
+
+// LockPID creates a lock file at path containing the PID of the current
+// process. If the file exists and its process is still running, an ErrLocked
+// error is returned. Lock files from processes that are not running anymore
+// (stale locks) are replaced, including lock files with the PID of the current
+// process that were not created by it.
+//
+// Stale locks are taken over while holding an advisory lock on them (see
+// Lock), so only one of the processes replacing them at the same time
+// succeeds. On platforms where advisory locks are not supported, this is not
+// guaranteed.
+func LockPID(path string) (*PIDLock, error) {
+	for i := 0; i < 2; i++ {
+		l, err := createPIDLock(path)
+		if err == nil {
+			return l, nil
+		}
+
+		if !errors.Is(err, fs.ErrExist) {
+			return nil, ErrLock.Wrap(err)
+		}
+
+		if err := removeStalePID(path); err != nil {
+			return nil, err
+		}
+	}
+
+	return nil, ErrLocked.Wrap(&PIDLockError{Path: path})
+}
+
+// ReadPID reads the PID from the lock file at path.
+func ReadPID(path string) (int, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return 0, err //nolint:wrapcheck
+	}
+
+	return parsePID(data)
+}
+
+// PIDLock is a lock file owned by the current process.
+type PIDLock struct {
+	path string
+}
+
+// pidLocks are the lock files held by the current process. Lock files with
+// its PID may be from a previous process that had the same PID (e.g. after
+// restarting a container), so they are only considered in use if they are
+// held.
+var pidLocks = struct {
+	sync.Mutex
+	paths map[*PIDLock]string
+}{paths: map[*PIDLock]string{}}
+
+// Path returns the lock file path.
+func (l *PIDLock) Path() string {
+	return l.path
+}
+
+// Unlock removes the lock file.
+func (l *PIDLock) Unlock() error {
+	err := os.Remove(l.path)
+
+	pidLocks.Lock()
+	delete(pidLocks.paths, l)
+	pidLocks.Unlock()
+
+	if err != nil {
+		return ErrLock.Wrap(err)
+	}
+
+	return nil
+}
+
+// PIDLockError describes a lock file held by another process.
+type PIDLockError struct {
+	Path string
+
+	// PID is the process holding the lock, it is 0 if unknown.
+	PID int
+}
+
+func (e *PIDLockError) Error() string {
+	if e.PID == 0 {
+		return e.Path
+	}
+
+	return e.Path + ": held by process " + strconv.Itoa(e.PID)
+}
+
+// createPIDLock creates the lock file at path and registers it as held by
+// the current process. Registration is done before other goroutines can check
+// the lock file, see pidAlive.
+func createPIDLock(path string) (*PIDLock, error) {
+	abs, err := filepath.Abs(path)
+	if err != nil {
+		return nil, err //nolint:wrapcheck
+	}
+
+	pidLocks.Lock()
+	defer pidLocks.Unlock()
+
+	if err := createPIDFile(path); err != nil {
+		return nil, err
+	}
+
+	l := &PIDLock{path: path}
+	pidLocks.paths[l] = abs
+
+	return l, nil
+}
+
+// createPIDFile writes the PID of the current process into a temporary file
+// and links it to path, so the lock file is never seen without its content.
+func createPIDFile(path string) error {
+	dir, name := filepath.Dir(path), filepath.Base(path)
+
+	f, err := os.CreateTemp(dir, "."+name+".tmp-*")
+	if err != nil {
+		return err //nolint:wrapcheck
+	}
+
+	defer os.Remove(f.Name())
+
+	_, err = f.WriteString(strconv.Itoa(os.Getpid()) + "\n")
+	if cerr := f.Close(); err == nil {
+		err = cerr
+	}
+
+	if err != nil {
+		return err //nolint:wrapcheck
+	}
+
+	return os.Link(f.Name(), path) //nolint:wrapcheck
+}
+
+// pidAlive reports if the process pid holds the lock file at path.
+func pidAlive(pid int, path string) bool {
+	if pid != os.Getpid() {
+		return processAlive(pid)
+	}
+
+	abs, err := filepath.Abs(path)
+	if err != nil {
+		return true
+	}
+
+	pidLocks.Lock()
+	defer pidLocks.Unlock()
+
+	for _, p := range pidLocks.paths {
+		if p == abs {
+			return true
+		}
+	}
+
+	return false
+}
+
+func parsePID(data []byte) (int, error) {
+	pid, err := strconv.Atoi(strings.TrimSpace(string(data)))
+	if err != nil || pid <= 0 {
+		return 0, ErrInvalidPID
+	}
+
+	return pid, nil
+}
+
+// removeStalePID removes the lock file at path if its process is not running.
+// The file is locked while being checked, and it is only removed if path
+// still refers to it, so a lock file created by another process after
+// removing the stale one is never removed. A nil error means the lock may be
+// created again.
+func removeStalePID(path string) error {
+	f, err := os.Open(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil
+	} else if err != nil {
+		return ErrLock.Wrap(err)
+	}
+
+	// Closing the file releases the lock.
+	defer f.Close()
+
+	for {
+		err := flock(f, false)
+		if err == nil || errors.Is(err, ErrNotSupported) {
+			break
+		}
+
+		if !errors.Is(err, errWouldBlock) {
+			return ErrLock.Wrap(err)
+		}
+
+		// Another process is checking the lock file.
+		time.Sleep(time.Millisecond)
+	}
+
+	lfi, err := f.Stat()
+	if err != nil {
+		return ErrLock.Wrap(err)
+	}
+
+	fi, err := os.Stat(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil
+	} else if err != nil {
+		return ErrLock.Wrap(err)
+	}
+
+	if !os.SameFile(lfi, fi) {
+		return nil
+	}
+
+	data, err := io.ReadAll(f)
+	if err != nil {
+		return ErrLock.Wrap(err)
+	}
+
+	if pid, err := parsePID(data); err == nil && pidAlive(pid, path) {
+		return ErrLocked.Wrap(&PIDLockError{Path: path, PID: pid})
+	}
+
+	if err := os.Remove(path); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return ErrLock.Wrap(err)
+	}
+
+	return nil
+}
@@ -0,0 +1,261 @@
+// Copyright 2026 Miguel Angel Rivera Notararigo. All rights reserved.
+// This source code was released under the MIT license.
+
+package os_test
+
+import (
+	"context"
+	"errors"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"testing"
+	"time"
+
+	ntos "go.ntrrg.dev/ntgo/os"
+)
+
+func TestLock(t *testing.T) {
+	t.Parallel()
+
+	dir, err := os.MkdirTemp("", "ntgo-os-lock")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "lock")
+
+	l, err := ntos.Lock(context.Background(), path)
+	if errors.Is(err, ntos.ErrNotSupported) {
+		t.Skip(err)
+	} else if err != nil {
+		t.Fatalf("Lock failed: %v", err)
+	}
+
+	if _, err := ntos.TryLock(path); !errors.Is(err, ntos.ErrLocked) {
+		t.Errorf("invalid error. got: %v, want: %v", err, ntos.ErrLocked)
+	}
+
+	_, err = ntos.TryLock(path, ntos.WithShared())
+	if !errors.Is(err, ntos.ErrLocked) {
+		t.Errorf("shared lock acquired while exclusive lock is held: %v", err)
+	}
+
+	_, err = ntos.Lock(
+		context.Background(), path,
+		ntos.WithLockTimeout(50*time.Millisecond),
+		ntos.WithLockRetry(10*time.Millisecond),
+	)
+
+	want := context.DeadlineExceeded
+	if !errors.Is(err, ntos.ErrLocked) || !errors.Is(err, want) {
+		t.Errorf("invalid error. got: %v, want: %v", err, want)
+	}
+
+	done := make(chan error)
+
+	go func() {
+		l, err := ntos.Lock(context.Background(), path)
+		if err == nil {
+			err = l.Unlock()
+		}
+
+		done <- err
+	}()
+
+	if err := l.Unlock(); err != nil {
+		t.Fatalf("Unlock failed: %v", err)
+	}
+
+	if err := <-done; err != nil {
+		t.Errorf("cannot lock after unlock: %v", err)
+	}
+}
+
+func TestLock_shared(t *testing.T) {
+	t.Parallel()
+
+	dir, err := os.MkdirTemp("", "ntgo-os-lock_shared")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "lock")
+
+	for i := 0; i < 2; i++ {
+		l, err := ntos.TryLock(path, ntos.WithShared())
+		if errors.Is(err, ntos.ErrNotSupported) {
+			t.Skip(err)
+		} else if err != nil {
+			t.Fatalf("TryLock failed: %v", err)
+		}
+
+		defer l.Unlock()
+	}
+
+	if _, err := ntos.TryLock(path); !errors.Is(err, ntos.ErrLocked) {
+		t.Errorf("exclusive lock acquired while shared lock is held: %v", err)
+	}
+}
+
+func TestLockPID(t *testing.T) {
+	t.Parallel()
+
+	dir, err := os.MkdirTemp("", "ntgo-os-lockpid")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "app.pid")
+
+	l, err := ntos.LockPID(path)
+	if err != nil {
+		t.Fatalf("LockPID failed: %v", err)
+	}
+
+	if pid, err := ntos.ReadPID(path); err != nil || pid != os.Getpid() {
+		t.Errorf("invalid PID. got: %v (%v), want: %v", pid, err, os.Getpid())
+	}
+
+	var lerr *ntos.PIDLockError
+
+	_, err = ntos.LockPID(path)
+	if !errors.Is(err, ntos.ErrLocked) || !errors.As(err, &lerr) {
+		t.Fatalf("invalid error. got: %v, want: %v", err, ntos.ErrLocked)
+	}
+
+	if lerr.PID != os.Getpid() {
+		t.Errorf("invalid PID. got: %v, want: %v", lerr.PID, os.Getpid())
+	}
+
+	if err := l.Unlock(); err != nil {
+		t.Fatalf("Unlock failed: %v", err)
+	}
+
+	if _, err := os.Stat(path); err == nil {
+		t.Error("lock file was not removed")
+	}
+}
+
+func TestLockPID_stale(t *testing.T) {
+	t.Parallel()
+
+	dir, err := os.MkdirTemp("", "ntgo-os-lockpid_stale")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	defer os.RemoveAll(dir)
+
+	// Finished processes are reaped by Run, so their PID is not in use.
+	cmd := exec.Command(os.Args[0], "-test.run=^$")
+	if err := cmd.Run(); err != nil {
+		t.Fatal(err)
+	}
+
+	stale := strconv.Itoa(cmd.Process.Pid)
+
+	// Lock files with the current PID are from previous processes with the
+	// same PID if they are not held (e.g. PID 1 in containers).
+	own := strconv.Itoa(os.Getpid())
+
+	for _, data := range []string{stale, "invalid", own} {
+		path := filepath.Join(dir, "app.pid")
+
+		if err := os.WriteFile(path, []byte(data), 0o600); err != nil {
+			t.Fatal(err)
+		}
+
+		l, err := ntos.LockPID(path)
+		if err != nil {
+			t.Fatalf("stale lock %q was not replaced: %v", data, err)
+		}
+
+		if err := l.Unlock(); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func TestLockPID_staleConcurrent(t *testing.T) {
+	t.Parallel()
+
+	dir, err := os.MkdirTemp("", "ntgo-os-lockpid_staleconcurrent")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "app.pid")
+
+	l, err := ntos.TryLock(path)
+	if errors.Is(err, ntos.ErrNotSupported) {
+		t.Skip(err)
+	} else if err != nil {
+		t.Fatalf("TryLock failed: %v", err)
+	}
+
+	if err := l.Unlock(); err != nil {
+		t.Fatal(err)
+	}
+
+	cmd := exec.Command(os.Args[0], "-test.run=^$")
+	if err := cmd.Run(); err != nil {
+		t.Fatal(err)
+	}
+
+	stale := []byte(strconv.Itoa(cmd.Process.Pid))
+
+	// Races are not deterministic, so they are tried several times.
+	for i := 0; i < 200; i++ {
+		if err := os.WriteFile(path, stale, 0o600); err != nil {
+			t.Fatal(err)
+		}
+
+		const n = 32
+
+		locks := make(chan *ntos.PIDLock, n)
+		errs := make(chan error, n)
+		start := make(chan struct{})
+
+		for j := 0; j < n; j++ {
+			go func() {
+				<-start
+
+				l, err := ntos.LockPID(path)
+				if err == nil {
+					locks <- l
+				}
+
+				errs <- err
+			}()
+		}
+
+		close(start)
+
+		for j := 0; j < n; j++ {
+			err := <-errs
+			if err != nil && !errors.Is(err, ntos.ErrLocked) {
+				t.Errorf("invalid error. got: %v, want: %v", err, ntos.ErrLocked)
+			}
+		}
+
+		close(locks)
+
+		if len(locks) != 1 {
+			t.Fatalf("invalid number of locks. got: %d, want: %d", len(locks), 1)
+		}
+
+		for l := range locks {
+			l.Unlock() //nolint:errcheck
+		}
+	}
+}
@@ -0,0 +1,18 @@
+// Copyright 2026 Miguel Angel Rivera Notararigo. All rights reserved.
+// This source code was released under the MIT license.
+
+//go:build !windows && !plan9
+
+package os
+
+import (
+	"errors"
+	"syscall"
+)
+
+func processAlive(pid int) bool {
+	err := syscall.Kill(pid, 0)
+
+	// EPERM means the process exists, but belongs to another user.
+	return err == nil || errors.Is(err, syscall.EPERM)
+}
@@ -0,0 +1,36 @@
+// Copyright 2026 Miguel Angel Rivera Notararigo. All rights reserved.
+// This source code was released under the MIT license.
+
+package os
+
+import (
+	"errors"
+	"syscall"
+)
+
+const (
+	errInvalidParameter = syscall.Errno(87)
+	stillActive         = 259
+)
+
+func processAlive(pid int) bool {
+	h, err := syscall.OpenProcess(
+		syscall.PROCESS_QUERY_INFORMATION,
+		false,
+		uint32(pid),
+	)
+
+	if err != nil {
+		// Processes that can't be opened may be owned by another user.
+		return !errors.Is(err, errInvalidParameter)
+	}
+
+	defer syscall.CloseHandle(h) //nolint:errcheck
+
+	var code uint32
+	if err := syscall.GetExitCodeProcess(h, &code); err != nil {
+		return true
+	}
+
+	return code == stillActive
+}
@@ -0,0 +1,130 @@
+// Copyright 2026 Miguel Angel Rivera Notararigo. All rights reserved.
+// This source code was released under the MIT license.
+
+package os
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+)
+
+// Move moves src to dst. It tries to rename src first, if src and dst are in
+// different devices, src is copied into dst and then removed. Files and
+// directories are supported.
+//
+// Copies are done into a temporary path next to dst, which is renamed to dst
+// once the copy is complete, so if the copy fails, dst is not modified and src
+// is kept. Modification times are always preserved and symbolic links are
+// copied as links (as renaming does), opts may be used to customize the copy,
+// e.g. WithVerify for comparing checksums before removing src.
+func Move(dst, src string, opts ...CopyOption) error {
+	err := os.Rename(src, dst)
+	if err == nil || !isCrossDevice(err) {
+		return err //nolint:wrapcheck
+	}
+
+	sfi, err := os.Lstat(src)
+	if err != nil {
+		return NewCopyError(ErrCopyStat, src, dst, err)
+	}
+
+	copts := make([]CopyOption, 0, len(opts)+2)
+	copts = append(copts, WithSymlinks(SymlinkPreserve))
+	copts = append(copts, opts...)
+	copts = append(copts, WithTimes())
+
+	tmp, err := moveTemp(dst, src, sfi, copts)
+	if err != nil {
+		return err
+	}
+
+	if err := os.Rename(tmp, dst); err != nil {
+		os.RemoveAll(tmp)
+		return NewCopyError(ErrCopyRename, tmp, dst, err)
+	}
+
+	if err := os.RemoveAll(src); err != nil {
+		return NewCopyError(ErrCopyRemove, src, dst, err)
+	}
+
+	return nil
+}
+
+// moveTemp copies src into a temporary path in the dst directory. If the copy
+// fails, the temporary path is removed.
+func moveTemp(
+	dst, src string,
+	sfi os.FileInfo,
+	opts []CopyOption,
+) (string, error) {
+	var (
+		tmp string
+		err error
+	)
+
+	dir, pattern := filepath.Dir(dst), "."+filepath.Base(dst)+".tmp-*"
+
+	if sfi.IsDir() {
+		tmp, err = os.MkdirTemp(dir, pattern)
+	} else {
+		tmp, err = createTemp(dir, pattern)
+	}
+
+	if err != nil {
+		return "", NewCopyError(ErrCopyOpenDst, src, dst, err)
+	}
+
+	switch {
+	case sfi.IsDir():
+		err = CopyDir(tmp, src, sfi.Mode(), opts...)
+	case sfi.Mode()&os.ModeSymlink != 0:
+		if lerr := copySymlink(tmp, src); lerr != nil {
+			err = NewCopyError(ErrCopySymlink, src, tmp, lerr)
+		}
+	default:
+		err = CopyFile(tmp, src, sfi.Mode(), opts...)
+	}
+
+	// Temporary paths are created with restricted permissions.
+	if err == nil && sfi.Mode()&os.ModeSymlink == 0 {
+		if cerr := os.Chmod(tmp, sfi.Mode()); cerr != nil {
+			err = NewCopyError(ErrCopyAttr, src, tmp, cerr)
+		}
+	}
+
+	if err != nil {
+		os.RemoveAll(tmp)
+		return "", err
+	}
+
+	return tmp, nil
+}
+
+func createTemp(dir, pattern string) (string, error) {
+	f, err := os.CreateTemp(dir, pattern)
+	if err != nil {
+		return "", err //nolint:wrapcheck
+	}
+
+	if err := f.Close(); err != nil {
+		return "", err //nolint:wrapcheck
+	}
+
+	return f.Name(), nil
+}
+
+// copySymlink replaces dst with a symbolic link that has the same target as
+// src.
+func copySymlink(dst, src string) error {
+	target, err := os.Readlink(src)
+	if err != nil {
+		return err //nolint:wrapcheck
+	}
+
+	if err := os.Remove(dst); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return err //nolint:wrapcheck
+	}
+
+	return os.Symlink(target, dst) //nolint:wrapcheck
+}
@@ -0,0 +1,10 @@
+// Copyright 2026 Miguel Angel Rivera Notararigo. All rights reserved.
+// This source code was released under the MIT license.
+
+package os
+
+// Plan 9 can only rename files in the same directory, any other rename is
+// reported as a regular error.
+func isCrossDevice(err error) bool {
+	return false
+}
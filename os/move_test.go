@@ -0,0 +1,209 @@
+// Copyright 2026 Miguel Angel Rivera Notararigo. All rights reserved.
+// This source code was released under the MIT license.
+
+package os_test
+
+import (
+	"errors"
+	"io/fs"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+
+	ntos "go.ntrrg.dev/ntgo/os"
+)
+
+func TestMove(t *testing.T) {
+	t.Parallel()
+
+	dirs := []string{os.TempDir()}
+
+	// A different file system is needed for testing copies, /dev/shm is
+	// usually a tmpfs mount on Linux.
+	if fi, err := os.Stat("/dev/shm"); err == nil && fi.IsDir() {
+		dirs = append(dirs, "/dev/shm")
+	}
+
+	for _, base := range dirs {
+		src, err := os.MkdirTemp("", "ntgo-os-move-src")
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		defer os.RemoveAll(src)
+
+		dir, err := os.MkdirTemp(base, "ntgo-os-move-dst")
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		defer os.RemoveAll(dir)
+
+		writeTree(t, src, map[string]string{
+			"file.txt":     "hello, world!",
+			"sub/file.txt": "nested",
+		})
+
+		if err := os.Symlink("file.txt", filepath.Join(src, "link")); err != nil {
+			t.Fatal(err)
+		}
+
+		for _, name := range []string{"file.txt", "link"} {
+			from, to := filepath.Join(src, name), filepath.Join(dir, name)
+
+			if err := ntos.Move(to, from, ntos.WithVerify()); err != nil {
+				t.Fatalf("[%s] Move failed to move %s: %v", base, name, err)
+			}
+
+			if _, err := os.Lstat(from); !errors.Is(err, fs.ErrNotExist) {
+				t.Errorf("[%s] source %s was not removed: %v", base, name, err)
+			}
+		}
+
+		if target, err := os.Readlink(filepath.Join(dir, "link")); err != nil {
+			t.Errorf("[%s] symbolic link was not moved: %v", base, err)
+		} else if target != "file.txt" {
+			t.Errorf("[%s] invalid link target: %q", base, target)
+		}
+
+		ref := filepath.Join(dir, "reference")
+		if err := ntos.Copy(ref, src); err != nil {
+			t.Fatal(err)
+		}
+
+		dst := filepath.Join(dir, "directory")
+		if err := ntos.Move(dst, src); err != nil {
+			t.Fatalf("[%s] Move failed to move a directory: %v", base, err)
+		}
+
+		if _, err := os.Lstat(src); !errors.Is(err, fs.ErrNotExist) {
+			t.Errorf("[%s] source directory was not removed: %v", base, err)
+		}
+
+		if err := compareDirs(dst, ref); err != nil {
+			t.Errorf("[%s] %v", base, err)
+		}
+	}
+}
+
+func TestMove_symlinks(t *testing.T) {
+	t.Parallel()
+
+	if fi, err := os.Stat("/dev/shm"); err != nil || !fi.IsDir() {
+		t.Skip("a different file system is needed for copying files")
+	}
+
+	src, err := os.MkdirTemp("", "ntgo-os-move_symlinks-src")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	defer os.RemoveAll(src)
+
+	dir, err := os.MkdirTemp("/dev/shm", "ntgo-os-move_symlinks-dst")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	defer os.RemoveAll(dir)
+
+	writeTree(t, src, map[string]string{
+		"file.txt":     "hello, world!",
+		"sub/file.txt": "nested",
+	})
+
+	links := map[string]string{
+		"relative": "file.txt",
+		"absolute": filepath.Join(dir, "outside"),
+		"loop":     ".",
+		"sub/up":   "..",
+	}
+
+	for name, target := range links {
+		if err := os.Symlink(target, filepath.Join(src, name)); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	dst := filepath.Join(dir, "directory")
+	if err := ntos.Move(dst, src); err != nil {
+		t.Fatalf("Move failed to move a directory: %v", err)
+	}
+
+	for name, want := range links {
+		got, err := os.Readlink(filepath.Join(dst, name))
+		if err != nil {
+			t.Errorf("[%s] symbolic link was not moved: %v", name, err)
+		} else if got != want {
+			t.Errorf("[%s] invalid link target. got: %q, want: %q", name, got, want)
+		}
+	}
+}
+
+func TestMove_missing(t *testing.T) {
+	t.Parallel()
+
+	dir, err := os.MkdirTemp("", "ntgo-os-move_missing")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	defer os.RemoveAll(dir)
+
+	src := filepath.Join(dir, "missing")
+	if err := ntos.Move(filepath.Join(dir, "dst"), src); err == nil {
+		t.Error("Move succeeded without source")
+	}
+}
+
+func TestMove_rollback(t *testing.T) {
+	t.Parallel()
+
+	if fi, err := os.Stat("/dev/shm"); err != nil || !fi.IsDir() {
+		t.Skip("a different file system is needed for copying files")
+	}
+
+	src, err := os.MkdirTemp("", "ntgo-os-move_rollback-src")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	defer os.RemoveAll(src)
+
+	dir, err := os.MkdirTemp("/dev/shm", "ntgo-os-move_rollback-dst")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	defer os.RemoveAll(dir)
+
+	writeTree(t, src, map[string]string{"file.txt": "hello, world!"})
+
+	// Sockets can't be opened as regular files, so the copy fails after the
+	// first file.
+	l, err := net.Listen("unix", filepath.Join(src, "z.sock"))
+	if err != nil {
+		t.Skip("cannot create UNIX socket:", err)
+	}
+
+	defer l.Close()
+
+	dst := filepath.Join(dir, "directory")
+	if err := ntos.Move(dst, src); err == nil {
+		t.Fatal("Move succeeded copying a socket")
+	}
+
+	if _, err := os.Stat(filepath.Join(src, "file.txt")); err != nil {
+		t.Errorf("source was modified: %v", err)
+	}
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(entries) != 0 {
+		t.Errorf("partial copy was not removed: %v", entries)
+	}
+}
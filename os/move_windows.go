@@ -0,0 +1,16 @@
+// Copyright 2026 Miguel Angel Rivera Notararigo. All rights reserved.
+// This source code was released under the MIT license.
+
+package os
+
+import (
+	"errors"
+	"syscall"
+)
+
+const errorNotSameDevice syscall.Errno = 17
+
+func isCrossDevice(err error) bool {
+	return errors.Is(err, errorNotSameDevice) ||
+		errors.Is(err, syscall.EXDEV)
+}
@@ -0,0 +1,520 @@
+// Copyright 2026 Miguel Angel Rivera Notararigo. All rights reserved.
+// This source code was released under the MIT license.
+
+package os
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+)
+
+// Sync makes dst a mirror of src. It is a shortcut for creating a plan with
+// PlanSync and applying it.
+func Sync(dst, src string, opts ...SyncOption) (*SyncPlan, error) {
+	p, err := PlanSync(dst, src, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	return p, p.Apply()
+}
+
+// PlanSync compares src and dst directories and creates a plan with the
+// changes needed to make dst a mirror of src. No file is modified, so this is
+// useful for dry runs.
+func PlanSync(dst, src string, opts ...SyncOption) (*SyncPlan, error) {
+	o := newSyncOptions(opts)
+
+	if err := isInside(dst, src); err != nil {
+		return nil, err
+	}
+
+	sfi, err := os.Stat(src)
+	if err != nil {
+		return nil, NewCopyError(ErrCopyStat, src, dst, err)
+	}
+
+	if !sfi.IsDir() {
+		return nil, NewCopyError(ErrCopyNotDir, src, dst, nil)
+	}
+
+	p := &SyncPlan{Dst: dst, Src: src, opts: o}
+
+	if _, err := os.Lstat(dst); errors.Is(err, fs.ErrNotExist) {
+		p.add(SyncCreate, ".", true)
+		return p, nil
+	}
+
+	if err := p.planSource(); err != nil {
+		return nil, err
+	}
+
+	if o.delete {
+		if err := p.planExtra(); err != nil {
+			return nil, err
+		}
+	}
+
+	return p, nil
+}
+
+// SyncPlan is the list of changes needed to make Dst a mirror of Src.
+type SyncPlan struct {
+	Dst, Src string
+	Changes  []SyncChange
+
+	opts syncOptions
+}
+
+// Apply performs the planned changes. Deletions are done first, then
+// creations and updates in the order they were planned.
+func (p *SyncPlan) Apply() error {
+	for _, c := range p.Changes {
+		if c.Op != SyncDelete {
+			continue
+		}
+
+		src, dst := p.srcPath(c.Path), p.dstPath(c.Path)
+
+		if err := os.RemoveAll(dst); err != nil {
+			return NewCopyError(ErrCopyRemove, src, dst, err)
+		}
+	}
+
+	copts := append([]CopyOption{WithTimes()}, p.opts.copy...)
+
+	for _, c := range p.Changes {
+		var err error
+
+		src, dst := p.srcPath(c.Path), p.dstPath(c.Path)
+
+		switch {
+		case c.Op == SyncDelete:
+			continue
+		case p.preserved(c.Path):
+			if err := copySymlink(dst, src); err != nil {
+				return NewCopyError(ErrCopySymlink, src, dst, err)
+			}
+		case c.Dir && c.Op == SyncCreate:
+			err = copyDirPath(dst, src, copts, c.Path)
+		case c.Dir:
+			err = syncMode(dst, src)
+		default:
+			err = copyFileMode(dst, src, copts)
+		}
+
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (p *SyncPlan) add(op SyncOp, path string, dir bool) {
+	p.Changes = append(p.Changes, SyncChange{Op: op, Path: path, Dir: dir})
+}
+
+func (p *SyncPlan) dstPath(path string) string {
+	return filepath.Join(p.Dst, path)
+}
+
+func (p *SyncPlan) srcPath(path string) string {
+	return filepath.Join(p.Src, path)
+}
+
+// planSource looks for source files that are missing or outdated in the
+// destination.
+func (p *SyncPlan) planSource() error {
+	return p.planDir(p.Src, ".", newCopyOptions(p.opts.copy))
+}
+
+// planDir plans the content of the src directory, whose path relative to the
+// synchronized directories is prefix. Directories from followed symbolic
+// links are planned with their own walk, since filepath.WalkDir doesn't walk
+// symbolic links.
+func (p *SyncPlan) planDir(src, prefix string, o copyOptions) error {
+	fn := func(srcpath string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return NewCopyError(ErrCopyStat, srcpath, p.Dst, err)
+		}
+
+		// The root of followed directories was compared already.
+		if srcpath == src && prefix != "." {
+			return nil
+		}
+
+		rel, err := filepath.Rel(src, srcpath)
+		if err != nil {
+			return NewCopyError(ErrCopyPath, p.Src, p.Dst, err)
+		}
+
+		path := filepath.Join(prefix, rel)
+
+		sfi, err := d.Info()
+		if err != nil {
+			return NewCopyError(ErrCopyStat, srcpath, p.Dst, err)
+		}
+
+		if path != "." && !o.include(filepath.ToSlash(path), sfi) {
+			if d.IsDir() {
+				return filepath.SkipDir
+			}
+
+			return nil
+		}
+
+		// Options for walking the target of followed symbolic links.
+		var (
+			target string
+			to     = o
+		)
+
+		if sfi.Mode()&fs.ModeSymlink != 0 {
+			switch o.symlinks {
+			case SymlinkSkip:
+				return nil
+			case SymlinkFollow:
+				target, sfi, to, err = followSymlink(srcpath, o)
+				if err != nil {
+					dst := p.dstPath(path)
+					return NewCopyError(ErrCopySymlink, srcpath, dst, err)
+				}
+			}
+		}
+
+		op, err := p.compare(path, sfi)
+		if err != nil {
+			return err
+		}
+
+		if op != 0 {
+			p.add(op, path, sfi.IsDir())
+		}
+
+		switch {
+		case !sfi.IsDir():
+			return nil
+		case op == SyncCreate:
+			// New directories are copied completely.
+			return filepath.SkipDir
+		case target != "":
+			return p.planDir(target, path, to)
+		}
+
+		return nil
+	}
+
+	return filepath.WalkDir(src, fn) //nolint:wrapcheck
+}
+
+// planExtra looks for destination files that don't exist in the source.
+// Files excluded by the copy filter are kept.
+func (p *SyncPlan) planExtra() error {
+	o := newCopyOptions(p.opts.copy)
+
+	fn := func(dstpath string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return NewCopyError(ErrCopyStat, p.Src, dstpath, err)
+		}
+
+		path, err := filepath.Rel(p.Dst, dstpath)
+		if err != nil {
+			return NewCopyError(ErrCopyPath, p.Src, p.Dst, err)
+		}
+
+		if path != "." && o.filter != nil {
+			dfi, err := d.Info()
+			if err != nil {
+				return NewCopyError(ErrCopyStat, p.Src, dstpath, err)
+			}
+
+			if !o.include(filepath.ToSlash(path), dfi) {
+				if d.IsDir() {
+					return filepath.SkipDir
+				}
+
+				return nil
+			}
+		}
+
+		sfi, err := p.stat(path)
+
+		switch {
+		case errors.Is(err, fs.ErrNotExist):
+			p.add(SyncDelete, path, d.IsDir())
+		case err != nil:
+			return NewCopyError(ErrCopyStat, p.srcPath(path), p.dstPath(path), err)
+		case sfi.IsDir() || !d.IsDir():
+			return nil
+		}
+
+		// Directory content is deleted with it, type mismatches were planned
+		// for deletion already.
+		if d.IsDir() {
+			return filepath.SkipDir
+		}
+
+		return nil
+	}
+
+	return filepath.WalkDir(p.Dst, fn) //nolint:wrapcheck
+}
+
+// compare returns the operation needed to synchronize the destination
+// counterpart of path. Type mismatches are planned as deletions, followed by
+// a creation from the caller.
+func (p *SyncPlan) compare(path string, sfi fs.FileInfo) (SyncOp, error) {
+	dfi, err := os.Lstat(p.dstPath(path))
+
+	switch {
+	case errors.Is(err, fs.ErrNotExist):
+		return SyncCreate, nil
+	case err != nil:
+		return 0, NewCopyError(ErrCopyStat, p.srcPath(path), p.dstPath(path), err)
+	case sfi.IsDir() != dfi.IsDir():
+		p.add(SyncDelete, path, dfi.IsDir())
+		return SyncCreate, nil
+	case sfi.Mode()&fs.ModeSymlink != 0:
+		return p.compareSymlink(path, dfi)
+	case sfi.Mode() != dfi.Mode():
+		return SyncUpdate, nil
+	case sfi.IsDir():
+		return 0, nil
+	}
+
+	equal, err := p.opts.compare.equal(
+		p.srcPath(path), p.dstPath(path),
+		sfi, dfi,
+	)
+
+	if err != nil {
+		return 0, err
+	}
+
+	if equal {
+		return 0, nil
+	}
+
+	return SyncUpdate, nil
+}
+
+// compareSymlink compares preserved symbolic links by their target.
+func (p *SyncPlan) compareSymlink(
+	path string,
+	dfi fs.FileInfo,
+) (SyncOp, error) {
+	src, dst := p.srcPath(path), p.dstPath(path)
+
+	if dfi.Mode()&fs.ModeSymlink == 0 {
+		p.add(SyncDelete, path, dfi.IsDir())
+		return SyncCreate, nil
+	}
+
+	starget, err := os.Readlink(src)
+	if err != nil {
+		return 0, NewCopyError(ErrCopySymlink, src, dst, err)
+	}
+
+	dtarget, err := os.Readlink(dst)
+	if err != nil {
+		return 0, NewCopyError(ErrCopySymlink, src, dst, err)
+	}
+
+	if starget == dtarget {
+		return 0, nil
+	}
+
+	return SyncUpdate, nil
+}
+
+// preserved reports if path is a symbolic link that is copied as is.
+func (p *SyncPlan) preserved(path string) bool {
+	if newCopyOptions(p.opts.copy).symlinks != SymlinkPreserve {
+		return false
+	}
+
+	fi, err := os.Lstat(p.srcPath(path))
+
+	return err == nil && fi.Mode()&fs.ModeSymlink != 0
+}
+
+// stat returns the information of the source counterpart of path, symbolic
+// links are followed if they are copied that way.
+func (p *SyncPlan) stat(path string) (fs.FileInfo, error) {
+	if newCopyOptions(p.opts.copy).symlinks == SymlinkFollow {
+		return os.Stat(p.srcPath(path)) //nolint:wrapcheck
+	}
+
+	return os.Lstat(p.srcPath(path)) //nolint:wrapcheck
+}
+
+// SyncChange is a single change from a SyncPlan.
+type SyncChange struct {
+	Op SyncOp
+
+	// Path is relative to the synchronized directories.
+	Path string
+
+	// Dir reports if Path is a directory.
+	Dir bool
+}
+
+// String returns c in a human-readable form, e.g. "+ dir/", "~ file.txt" or
+// "- old.txt".
+func (c SyncChange) String() string {
+	s := c.Op.String() + " " + filepath.ToSlash(c.Path)
+
+	if c.Dir && c.Path != "." {
+		s += "/"
+	}
+
+	return s
+}
+
+// SyncOp is the kind of change needed to synchronize a path.
+type SyncOp int
+
+const (
+	// SyncCreate copies a path that is missing in the destination.
+	SyncCreate SyncOp = iota + 1
+
+	// SyncUpdate replaces an outdated path in the destination.
+	SyncUpdate
+
+	// SyncDelete removes a path that is missing in the source.
+	SyncDelete
+)
+
+// String returns the symbol of op.
+func (op SyncOp) String() string {
+	switch op {
+	case SyncCreate:
+		return "+"
+	case SyncUpdate:
+		return "~"
+	case SyncDelete:
+		return "-"
+	}
+
+	return "?"
+}
+
+// SyncCompare is the method used for detecting changed files.
+type SyncCompare int
+
+const (
+	// SyncBySizeAndModTime considers files with the same size and modification
+	// time as equal. This is the default method.
+	SyncBySizeAndModTime SyncCompare = iota
+
+	// SyncByContent compares the SHA-256 hash of files with the same size.
+	SyncByContent
+)
+
+func (m SyncCompare) equal(
+	src, dst string,
+	sfi, dfi fs.FileInfo,
+) (bool, error) {
+	if sfi.Size() != dfi.Size() {
+		return false, nil
+	}
+
+	if m == SyncBySizeAndModTime {
+		return sfi.ModTime().Equal(dfi.ModTime()), nil
+	}
+
+	shash, err := hashFile(src, sha256.New)
+	if err != nil {
+		return false, NewCopyError(ErrCopyOpenSrc, src, dst, err)
+	}
+
+	dhash, err := hashFile(dst, sha256.New)
+	if err != nil {
+		return false, NewCopyError(ErrCopyOpenDst, src, dst, err)
+	}
+
+	return bytes.Equal(shash, dhash), nil
+}
+
+// SyncOption customizes synchronization operations.
+type SyncOption func(*syncOptions)
+
+// WithCompare sets the method used for detecting changed files.
+func WithCompare(m SyncCompare) SyncOption {
+	return func(o *syncOptions) {
+		o.compare = m
+	}
+}
+
+// WithCopyOptions sets options for the underlying copy operations.
+func WithCopyOptions(opts ...CopyOption) SyncOption {
+	return func(o *syncOptions) {
+		o.copy = append(o.copy, opts...)
+	}
+}
+
+// WithoutDelete keeps destination files that don't exist in the source.
+func WithoutDelete() SyncOption {
+	return func(o *syncOptions) {
+		o.delete = false
+	}
+}
+
+type syncOptions struct {
+	compare SyncCompare
+	copy    []CopyOption
+	delete  bool
+}
+
+func newSyncOptions(opts []SyncOption) syncOptions {
+	o := syncOptions{delete: true}
+
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	return o
+}
+
+// copyDirPath copies the src directory, whose path relative to the
+// synchronized directories is path.
+func copyDirPath(dst, src string, opts []CopyOption, path string) error {
+	prefix := ""
+	if path != "." {
+		prefix = filepath.ToSlash(path) + "/"
+	}
+
+	return copyDir(dst, src, newCopyOptions(opts), prefix)
+}
+
+func copyFileMode(dst, src string, opts []CopyOption) error {
+	fi, err := os.Stat(src)
+	if err != nil {
+		return NewCopyError(ErrCopyStat, src, dst, err)
+	}
+
+	if err := CopyFile(dst, src, fi.Mode(), opts...); err != nil {
+		return err
+	}
+
+	// CopyFile only sets the mode of new files.
+	return syncMode(dst, src)
+}
+
+func syncMode(dst, src string) error {
+	fi, err := os.Stat(src)
+	if err != nil {
+		return NewCopyError(ErrCopyStat, src, dst, err)
+	}
+
+	if err := os.Chmod(dst, fi.Mode()); err != nil {
+		return NewCopyError(ErrCopyAttr, src, dst, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,415 @@
+// Copyright 2026 Miguel Angel Rivera Notararigo. All rights reserved.
+// This source code was released under the MIT license.
+
+package os_test
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	ntos "go.ntrrg.dev/ntgo/os"
+)
+
+func TestSync(t *testing.T) {
+	t.Parallel()
+
+	dir, err := os.MkdirTemp("", "ntgo-os-sync")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	defer os.RemoveAll(dir)
+
+	src := filepath.Join(dir, "source")
+	dst := filepath.Join(dir, "destination")
+
+	writeTree(t, src, map[string]string{
+		"same.txt":       "same",
+		"changed.txt":    "new content",
+		"new.txt":        "new",
+		"sub/nested.txt": "nested",
+		"type":           "now a file",
+	})
+
+	if _, err := ntos.Sync(dst, src); err != nil {
+		t.Fatalf("Sync failed to create the mirror: %v", err)
+	}
+
+	writeTree(t, src, map[string]string{"changed.txt": "changed content"})
+	writeTree(t, dst, map[string]string{"extra/file.txt": "extra"})
+
+	if err := os.Remove(filepath.Join(src, "new.txt")); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := os.RemoveAll(filepath.Join(dst, "sub")); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := os.Remove(filepath.Join(dst, "type")); err != nil {
+		t.Fatal(err)
+	}
+
+	writeTree(t, dst, map[string]string{"type/file.txt": "was a directory"})
+
+	p, err := ntos.PlanSync(dst, src)
+	if err != nil {
+		t.Fatalf("PlanSync failed: %v", err)
+	}
+
+	want := []string{
+		"~ changed.txt",
+		"+ sub/",
+		"- type/",
+		"+ type",
+		"- extra/",
+		"- new.txt",
+	}
+
+	got := planStrings(p)
+	if strings.Join(got, ", ") != strings.Join(want, ", ") {
+		t.Fatalf("invalid plan. got: %q, want: %q", got, want)
+	}
+
+	// Planning is a dry run.
+	if _, err := os.Stat(filepath.Join(dst, "extra")); err != nil {
+		t.Fatalf("PlanSync modified the destination: %v", err)
+	}
+
+	if err := p.Apply(); err != nil {
+		t.Fatalf("cannot apply plan: %v", err)
+	}
+
+	if err := compareDirs(dst, src); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := compareDirs(src, dst); err != nil {
+		t.Fatal(err)
+	}
+
+	p, err = ntos.PlanSync(dst, src)
+	if err != nil {
+		t.Fatalf("PlanSync failed: %v", err)
+	}
+
+	if len(p.Changes) != 0 {
+		t.Errorf("synchronized directories have changes: %q", planStrings(p))
+	}
+}
+
+func TestSync_options(t *testing.T) {
+	t.Parallel()
+
+	dir, err := os.MkdirTemp("", "ntgo-os-sync_options")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	defer os.RemoveAll(dir)
+
+	src := filepath.Join(dir, "source")
+	dst := filepath.Join(dir, "destination")
+
+	writeTree(t, src, map[string]string{"file.txt": "source"})
+	writeTree(t, dst, map[string]string{"file.txt": "source", "extra": "x"})
+
+	old := time.Now().Add(-time.Hour)
+	if err := os.Chtimes(filepath.Join(dst, "file.txt"), old, old); err != nil {
+		t.Fatal(err)
+	}
+
+	opts := []ntos.SyncOption{
+		ntos.WithCompare(ntos.SyncByContent),
+		ntos.WithoutDelete(),
+	}
+
+	p, err := ntos.PlanSync(dst, src, opts...)
+	if err != nil {
+		t.Fatalf("PlanSync failed: %v", err)
+	}
+
+	if len(p.Changes) != 0 {
+		t.Errorf("invalid plan. got: %q, want: no changes", planStrings(p))
+	}
+
+	p, err = ntos.PlanSync(dst, src)
+	if err != nil {
+		t.Fatalf("PlanSync failed: %v", err)
+	}
+
+	// Modification times are different.
+	want := "~ file.txt, - extra"
+	if got := strings.Join(planStrings(p), ", "); got != want {
+		t.Errorf("invalid plan. got: %q, want: %q", got, want)
+	}
+}
+
+func TestSync_symlinks(t *testing.T) {
+	t.Parallel()
+
+	dir, err := os.MkdirTemp("", "ntgo-os-sync_symlinks")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	defer os.RemoveAll(dir)
+
+	cases := []struct {
+		label string
+		mode  ntos.SymlinkMode
+		want  string
+	}{
+		{
+			label: "Follow",
+			mode:  ntos.SymlinkFollow,
+			want:  "~ link, ~ linkdir/nested.txt, ~ sub/nested.txt",
+		},
+		{
+			label: "Preserve",
+			mode:  ntos.SymlinkPreserve,
+			want:  "~ link, ~ sub/nested.txt",
+		},
+	}
+
+	for _, c := range cases {
+		src := filepath.Join(dir, c.label, "source")
+		dst := filepath.Join(dir, c.label, "destination")
+		opt := ntos.WithCopyOptions(ntos.WithSymlinks(c.mode))
+
+		writeTree(t, src, map[string]string{
+			"file.txt":       "file",
+			"sub/nested.txt": "nested",
+		})
+
+		links := map[string]string{"link": "file.txt", "linkdir": "sub"}
+		for name, target := range links {
+			if err := os.Symlink(target, filepath.Join(src, name)); err != nil {
+				t.Fatal(err)
+			}
+		}
+
+		plan := func() *ntos.SyncPlan {
+			t.Helper()
+
+			p, err := ntos.PlanSync(dst, src, opt)
+			if err != nil {
+				t.Fatalf("[%s] PlanSync failed: %v", c.label, err)
+			}
+
+			return p
+		}
+
+		if _, err := ntos.Sync(dst, src, opt); err != nil {
+			t.Fatalf("[%s] Sync failed to create the mirror: %v", c.label, err)
+		}
+
+		if p := plan(); len(p.Changes) != 0 {
+			t.Errorf("[%s] mirror has changes: %q", c.label, planStrings(p))
+		}
+
+		writeTree(t, src, map[string]string{"sub/nested.txt": "changed nested"})
+
+		if err := os.Remove(filepath.Join(src, "link")); err != nil {
+			t.Fatal(err)
+		}
+
+		err := os.Symlink("sub/nested.txt", filepath.Join(src, "link"))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		p := plan()
+		if got := strings.Join(planStrings(p), ", "); got != c.want {
+			t.Errorf("[%s] invalid plan. got: %q, want: %q", c.label, got, c.want)
+		}
+
+		if err := p.Apply(); err != nil {
+			t.Fatalf("[%s] cannot apply plan: %v", c.label, err)
+		}
+
+		if p := plan(); len(p.Changes) != 0 {
+			t.Errorf("[%s] mirror has changes: %q", c.label, planStrings(p))
+		}
+
+		data, err := os.ReadFile(filepath.Join(dst, "link"))
+		if err != nil || string(data) != "changed nested" {
+			t.Errorf("[%s] invalid link content: %q (%v)", c.label, data, err)
+		}
+
+		fi, err := os.Lstat(filepath.Join(dst, "link"))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		isLink := fi.Mode()&os.ModeSymlink != 0
+		if isLink != (c.mode == ntos.SymlinkPreserve) {
+			t.Errorf("[%s] invalid link type: %v", c.label, fi.Mode())
+		}
+	}
+}
+
+func TestSync_filter(t *testing.T) {
+	t.Parallel()
+
+	dir, err := os.MkdirTemp("", "ntgo-os-sync_filter")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	defer os.RemoveAll(dir)
+
+	src := filepath.Join(dir, "source")
+	dst := filepath.Join(dir, "destination")
+
+	writeTree(t, src, map[string]string{
+		"file.txt":     "file",
+		"skip.log":     "skipped",
+		"cache/data":   "skipped",
+		"new/file.txt": "new",
+		"new/skip.log": "skipped",
+		"new/data":     "skipped",
+		"nested/data":  "kept",
+	})
+
+	writeTree(t, dst, map[string]string{
+		"extra.txt":  "extra",
+		"extra.log":  "kept",
+		"cache/data": "kept",
+	})
+
+	opt := ntos.WithCopyOptions(ntos.WithFilter(
+		ntos.Exclude("*.log", "cache", "new/data"),
+	))
+
+	p, err := ntos.PlanSync(dst, src, opt)
+	if err != nil {
+		t.Fatalf("PlanSync failed: %v", err)
+	}
+
+	want := "+ file.txt, + nested/, + new/, - extra.txt"
+	if got := strings.Join(planStrings(p), ", "); got != want {
+		t.Fatalf("invalid plan. got: %q, want: %q", got, want)
+	}
+
+	if err := p.Apply(); err != nil {
+		t.Fatalf("cannot apply plan: %v", err)
+	}
+
+	for name, want := range map[string]bool{
+		"file.txt":     true,
+		"extra.txt":    false,
+		"extra.log":    true,
+		"skip.log":     false,
+		"cache/data":   true,
+		"new/file.txt": true,
+		"new/skip.log": false,
+		"new/data":     false,
+		"nested/data":  true,
+	} {
+		_, err := os.Lstat(filepath.Join(dst, name))
+		if got := err == nil; got != want {
+			t.Errorf("[%s] invalid existence. got: %v, want: %v", name, got, want)
+		}
+	}
+
+	data, err := os.ReadFile(filepath.Join(dst, "cache", "data"))
+	if err != nil || string(data) != "kept" {
+		t.Errorf("excluded file was modified: %q (%v)", data, err)
+	}
+}
+
+func TestSync_symlinkSiblings(t *testing.T) {
+	t.Parallel()
+
+	dir, err := os.MkdirTemp("", "ntgo-os-sync_symlinksiblings")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	defer os.RemoveAll(dir)
+
+	src := filepath.Join(dir, "source")
+	dst := filepath.Join(dir, "destination")
+	external := filepath.Join(dir, "external")
+
+	// Existing directories are walked.
+	files := map[string]string{"a/file.txt": "a", "c/file.txt": "c"}
+	writeTree(t, src, files)
+	writeTree(t, dst, files)
+	writeTree(t, external, map[string]string{"file.txt": "external"})
+
+	links := map[string]string{
+		"a/l1": external,
+		"c/l2": filepath.Join(src, "a"),
+	}
+
+	for name, target := range links {
+		if err := os.Symlink(target, filepath.Join(src, name)); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// Followed links from an entry don't affect its siblings.
+	p, err := ntos.PlanSync(dst, src)
+	if err != nil {
+		t.Fatalf("PlanSync failed: %v", err)
+	}
+
+	if err := p.Apply(); err != nil {
+		t.Fatalf("cannot apply plan: %v", err)
+	}
+
+	data, err := os.ReadFile(filepath.Join(dst, "c", "l2", "l1", "file.txt"))
+	if err != nil || string(data) != "external" {
+		t.Errorf("invalid content: %q (%v)", data, err)
+	}
+}
+
+func TestSync_insideItself(t *testing.T) {
+	t.Parallel()
+
+	dir, err := os.MkdirTemp("", "ntgo-os-sync_inside")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	defer os.RemoveAll(dir)
+
+	var cerr *ntos.CopyError
+
+	_, err = ntos.Sync(filepath.Join(dir, "subdir"), dir)
+	if !errors.As(err, &cerr) {
+		t.Errorf("Sync succeeded mirroring a directory into itself: %v", err)
+	}
+}
+
+func planStrings(p *ntos.SyncPlan) []string {
+	s := make([]string, 0, len(p.Changes))
+
+	for _, c := range p.Changes {
+		s = append(s, c.String())
+	}
+
+	return s
+}
+
+func writeTree(t *testing.T, root string, files map[string]string) {
+	t.Helper()
+
+	for name, data := range files {
+		path := filepath.Join(root, name)
+
+		if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+			t.Fatal(err)
+		}
+
+		if err := os.WriteFile(path, []byte(data), 0o600); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
@@ -0,0 +1,9 @@
+// Copyright 2026 Miguel Angel Rivera Notararigo. All rights reserved.
+// This source code was released under the MIT license.
+
+package os
+
+const (
+	sysCopyFileRange uintptr = 391
+	sysFICLONE       uintptr = 0x40049409
+)
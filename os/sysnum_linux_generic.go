@@ -0,0 +1,11 @@
+// Copyright 2026 Miguel Angel Rivera Notararigo. All rights reserved.
+// This source code was released under the MIT license.
+
+//go:build linux && (arm64 || loong64 || riscv64)
+
+package os
+
+const (
+	sysCopyFileRange uintptr = 285
+	sysFICLONE       uintptr = 0x40049409
+)
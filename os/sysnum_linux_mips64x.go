@@ -0,0 +1,11 @@
+// Copyright 2026 Miguel Angel Rivera Notararigo. All rights reserved.
+// This source code was released under the MIT license.
+
+//go:build linux && (mips64 || mips64le)
+
+package os
+
+const (
+	sysCopyFileRange uintptr = 5320
+	sysFICLONE       uintptr = 0x80049409
+)
@@ -0,0 +1,11 @@
+// Copyright 2026 Miguel Angel Rivera Notararigo. All rights reserved.
+// This source code was released under the MIT license.
+
+//go:build linux && (mips || mipsle)
+
+package os
+
+const (
+	sysCopyFileRange uintptr = 4360
+	sysFICLONE       uintptr = 0x80049409
+)
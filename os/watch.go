@@ -0,0 +1,464 @@
+// Copyright 2026 Miguel Angel Rivera Notararigo. All rights reserved.
+// This source code was released under the MIT license.
+
+package os
+
+import (
+	"context"
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Watch watches the root directory for changes until ctx is done. Events are
+// delivered through Watcher.Events, which is closed when ctx is done.
+//
+// Changes are detected with native notifications when supported by the
+// platform (inotify on Linux), polling is used otherwise. Events for the same
+// path are coalesced into a single event until no change happens during the
+// debounce interval (see WithDebounce), or the maximum wait is reached (see
+// WithMaxWait).
+func Watch(
+	ctx context.Context,
+	root string,
+	opts ...WatchOption,
+) (*Watcher, error) {
+	o := newWatchOptions(opts)
+	root = filepath.Clean(root)
+
+	fi, err := os.Stat(root)
+	if err != nil {
+		return nil, ErrWatch.Wrap(err)
+	}
+
+	if !fi.IsDir() {
+		return nil, ErrWatchNotDir
+	}
+
+	var src watchSource
+
+	if o.poll == 0 {
+		src, err = newNativeWatch(root, o)
+		if err != nil {
+			o.poll = defaultPollInterval
+		}
+	}
+
+	if src == nil {
+		src, err = newPollWatch(root, o)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	events := make(chan WatchEvent)
+	errs := make(chan error, 16)
+	raw := make(chan WatchEvent)
+
+	go func() {
+		src.run(ctx, raw, errs)
+		close(raw)
+	}()
+
+	d := &watchDispatcher{
+		debounce: o.debounce,
+		maxWait:  o.maxWait,
+		out:      events,
+	}
+
+	if d.maxWait == 0 {
+		d.maxWait = 10 * d.debounce
+	}
+
+	go d.run(ctx, raw, errs)
+
+	return &Watcher{Events: events, Errors: errs}, nil
+}
+
+// Watcher delivers file system changes.
+type Watcher struct {
+	// Events delivers changes, it is closed when the watcher stops.
+	Events <-chan WatchEvent
+
+	// Errors delivers non-fatal errors, e.g. events dropped by the operating
+	// system. It is closed when the watcher stops.
+	Errors <-chan error
+}
+
+// WatchEvent is a file system change.
+type WatchEvent struct {
+	// Path is the changed file, it includes the watched directory as prefix.
+	Path string
+
+	// Op holds all the operations coalesced into this event.
+	Op WatchOp
+}
+
+// String returns e in a human-readable form.
+func (e WatchEvent) String() string {
+	return e.Op.String() + " " + e.Path
+}
+
+// WatchOp is a set of file system operations.
+type WatchOp uint32
+
+const (
+	// WatchCreate reports a new file.
+	WatchCreate WatchOp = 1 << iota
+
+	// WatchWrite reports a file content change.
+	WatchWrite
+
+	// WatchRemove reports a removed file.
+	WatchRemove
+
+	// WatchRename reports a file moved from its path.
+	WatchRename
+
+	// WatchChmod reports a file attributes change.
+	WatchChmod
+)
+
+// Has reports if op includes other.
+func (op WatchOp) Has(other WatchOp) bool {
+	return op&other != 0
+}
+
+// String returns the names of the operations in op separated by "|".
+func (op WatchOp) String() string {
+	names := []string{"CREATE", "WRITE", "REMOVE", "RENAME", "CHMOD"}
+
+	var ops []string
+
+	for i, name := range names {
+		if op.Has(1 << i) {
+			ops = append(ops, name)
+		}
+	}
+
+	return strings.Join(ops, "|")
+}
+
+// WatchOption customizes a file system watcher.
+type WatchOption func(*watchOptions)
+
+// WithDebounce sets how long changes are accumulated before being delivered.
+// If d is 0, events are delivered as soon as they are detected. Default is
+// 100ms.
+func WithDebounce(d time.Duration) WatchOption {
+	return func(o *watchOptions) {
+		o.debounce = d
+	}
+}
+
+// WithMaxWait sets the maximum time changes are accumulated, so continuous
+// changes (e.g. a file being appended to) are delivered at least once every
+// d. Default is 10 times the debounce interval.
+func WithMaxWait(d time.Duration) WatchOption {
+	return func(o *watchOptions) {
+		o.maxWait = d
+	}
+}
+
+// WithPolling forces the use of polling, checking for changes every d.
+func WithPolling(d time.Duration) WatchOption {
+	return func(o *watchOptions) {
+		o.poll = d
+	}
+}
+
+// WithRecursive watches subdirectories too.
+func WithRecursive() WatchOption {
+	return func(o *watchOptions) {
+		o.recursive = true
+	}
+}
+
+// WithWatchFilter sets a filter for selecting the files to watch. Excluded
+// directories are not watched.
+func WithWatchFilter(f Filter) WatchOption {
+	return func(o *watchOptions) {
+		o.filter = f
+	}
+}
+
+const (
+	defaultDebounce     = 100 * time.Millisecond
+	defaultPollInterval = time.Second
+)
+
+type watchOptions struct {
+	debounce  time.Duration
+	filter    Filter
+	maxWait   time.Duration
+	poll      time.Duration
+	recursive bool
+}
+
+func newWatchOptions(opts []WatchOption) watchOptions {
+	o := watchOptions{debounce: defaultDebounce}
+
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	return o
+}
+
+func (o watchOptions) include(name string, fi fs.FileInfo) bool {
+	return o.filter == nil || o.filter(name, fi)
+}
+
+// watchSource detects changes and sends them to raw until ctx is done.
+type watchSource interface {
+	run(ctx context.Context, raw chan<- WatchEvent, errs chan<- error)
+}
+
+// watchDispatcher coalesces raw events and delivers them.
+type watchDispatcher struct {
+	debounce time.Duration
+	maxWait  time.Duration
+	out      chan<- WatchEvent
+	pending  map[string]WatchOp
+}
+
+func (d *watchDispatcher) run(
+	ctx context.Context,
+	raw <-chan WatchEvent,
+	errs chan error,
+) {
+	defer close(errs)
+	defer close(d.out)
+
+	d.pending = map[string]WatchOp{}
+
+	timer := time.NewTimer(d.debounce)
+	stopTimer(timer)
+
+	// start is when the first pending event was received.
+	var start time.Time
+
+	for {
+		select {
+		case e, ok := <-raw:
+			if !ok {
+				return
+			}
+
+			if d.debounce == 0 {
+				d.send(ctx, e)
+				continue
+			}
+
+			if len(d.pending) == 0 {
+				start = time.Now()
+			}
+
+			d.pending[e.Path] |= e.Op
+
+			wait := d.debounce
+			if left := d.maxWait - time.Since(start); left < wait {
+				wait = left
+			}
+
+			stopTimer(timer)
+
+			if wait <= 0 {
+				d.flush(ctx)
+				continue
+			}
+
+			timer.Reset(wait)
+		case <-timer.C:
+			d.flush(ctx)
+		}
+	}
+}
+
+// stopTimer stops t and drains its channel, so a tick from before stopping it
+// is not received after resetting it.
+func stopTimer(t *time.Timer) {
+	if !t.Stop() {
+		select {
+		case <-t.C:
+		default:
+		}
+	}
+}
+
+// flush delivers pending events sorted by path.
+func (d *watchDispatcher) flush(ctx context.Context) {
+	paths := make([]string, 0, len(d.pending))
+
+	for p := range d.pending {
+		paths = append(paths, p)
+	}
+
+	sort.Strings(paths)
+
+	for _, p := range paths {
+		d.send(ctx, WatchEvent{Path: p, Op: d.pending[p]})
+		delete(d.pending, p)
+	}
+}
+
+func (d *watchDispatcher) send(ctx context.Context, e WatchEvent) {
+	select {
+	case d.out <- e:
+	case <-ctx.Done():
+	}
+}
+
+// sendWatchError delivers err if there is room in errs, errors are dropped
+// otherwise, so slow consumers don't block the watcher.
+func sendWatchError(errs chan<- error, err error) {
+	select {
+	case errs <- err:
+	default:
+	}
+}
+
+// pollWatch detects changes by comparing snapshots of the watched directory.
+type pollWatch struct {
+	root     string
+	opts     watchOptions
+	snapshot map[string]fileState
+}
+
+type fileState struct {
+	size    int64
+	modTime time.Time
+	mode    fs.FileMode
+}
+
+func newPollWatch(root string, o watchOptions) (*pollWatch, error) {
+	w := &pollWatch{root: root, opts: o}
+
+	s, err := w.scan()
+	if err != nil {
+		return nil, ErrWatch.Wrap(err)
+	}
+
+	w.snapshot = s
+
+	return w, nil
+}
+
+func (w *pollWatch) run(
+	ctx context.Context,
+	raw chan<- WatchEvent,
+	errs chan<- error,
+) {
+	ticker := time.NewTicker(w.opts.poll)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		s, err := w.scan()
+		if err != nil {
+			sendWatchError(errs, ErrWatch.Wrap(err))
+			continue
+		}
+
+		for _, e := range w.diff(s) {
+			select {
+			case raw <- e:
+			case <-ctx.Done():
+				return
+			}
+		}
+
+		w.snapshot = s
+	}
+}
+
+func (w *pollWatch) scan() (map[string]fileState, error) {
+	s := map[string]fileState{}
+
+	fn := func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			// Files may be removed while walking.
+			if path != w.root && errors.Is(err, fs.ErrNotExist) {
+				return nil
+			}
+
+			return err
+		}
+
+		if path == w.root {
+			return nil
+		}
+
+		fi, err := d.Info()
+		if err != nil {
+			return nil //nolint:nilerr
+		}
+
+		if !w.opts.include(relPath(w.root, path), fi) {
+			if d.IsDir() {
+				return filepath.SkipDir
+			}
+
+			return nil
+		}
+
+		s[path] = fileState{fi.Size(), fi.ModTime(), fi.Mode()}
+
+		if d.IsDir() && !w.opts.recursive {
+			return filepath.SkipDir
+		}
+
+		return nil
+	}
+
+	if err := filepath.WalkDir(w.root, fn); err != nil {
+		return nil, err //nolint:wrapcheck
+	}
+
+	return s, nil
+}
+
+func (w *pollWatch) diff(s map[string]fileState) []WatchEvent {
+	var events []WatchEvent
+
+	for p, cur := range s {
+		old, ok := w.snapshot[p]
+
+		var op WatchOp
+
+		switch {
+		case !ok:
+			op = WatchCreate
+		case old.mode != cur.mode:
+			op = WatchChmod
+		case cur.mode.IsDir():
+		case old.size != cur.size || !old.modTime.Equal(cur.modTime):
+			op = WatchWrite
+		}
+
+		if op != 0 {
+			events = append(events, WatchEvent{Path: p, Op: op})
+		}
+	}
+
+	for p := range w.snapshot {
+		if _, ok := s[p]; !ok {
+			events = append(events, WatchEvent{Path: p, Op: WatchRemove})
+		}
+	}
+
+	sort.Slice(events, func(i, j int) bool {
+		return events[i].Path < events[j].Path
+	})
+
+	return events
+}
@@ -0,0 +1,273 @@
+// Copyright 2026 Miguel Angel Rivera Notararigo. All rights reserved.
+// This source code was released under the MIT license.
+
+package os
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"syscall"
+	"time"
+	"unsafe"
+)
+
+const inotifyMask = syscall.IN_ATTRIB | syscall.IN_CREATE |
+	syscall.IN_DELETE | syscall.IN_DELETE_SELF | syscall.IN_MODIFY |
+	syscall.IN_MOVE_SELF | syscall.IN_MOVED_FROM | syscall.IN_MOVED_TO |
+	syscall.IN_ONLYDIR
+
+// inotifyWatch detects changes with Linux inotify.
+type inotifyWatch struct {
+	fd   int
+	f    *os.File
+	root string
+	opts watchOptions
+
+	// dirs maps watch descriptors to directory paths.
+	dirs map[int32]string
+}
+
+func newNativeWatch(root string, o watchOptions) (watchSource, error) {
+	fd, err := syscall.InotifyInit1(syscall.IN_CLOEXEC | syscall.IN_NONBLOCK)
+	if err != nil {
+		return nil, ErrWatch.Wrap(err)
+	}
+
+	// Non-blocking descriptors use the runtime poller, so closing the file
+	// interrupts pending reads.
+	w := &inotifyWatch{
+		fd:   fd,
+		f:    os.NewFile(uintptr(fd), "inotify"),
+		root: root,
+		opts: o,
+		dirs: map[int32]string{},
+	}
+
+	if _, err := w.addDir(root, false); err != nil {
+		w.f.Close()
+		return nil, ErrWatch.Wrap(err)
+	}
+
+	return w, nil
+}
+
+func (w *inotifyWatch) run(
+	ctx context.Context,
+	raw chan<- WatchEvent,
+	errs chan<- error,
+) {
+	stop := make(chan struct{})
+	defer close(stop)
+
+	go func() {
+		select {
+		case <-ctx.Done():
+		case <-stop:
+		}
+
+		w.f.Close()
+	}()
+
+	buf := make([]byte, 64*1024)
+
+	for {
+		n, err := w.f.Read(buf)
+		if err != nil {
+			if ctx.Err() == nil {
+				sendWatchError(errs, ErrWatch.Wrap(err))
+			}
+
+			return
+		}
+
+		for _, e := range w.parse(buf[:n], errs) {
+			select {
+			case raw <- e:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}
+}
+
+// parse converts a buffer of inotify events into watch events.
+func (w *inotifyWatch) parse(buf []byte, errs chan<- error) []WatchEvent {
+	var events []WatchEvent
+
+	for off := 0; off+syscall.SizeofInotifyEvent <= len(buf); {
+		ie := (*syscall.InotifyEvent)(unsafe.Pointer(&buf[off]))
+		off += syscall.SizeofInotifyEvent
+
+		name := buf[off : off+int(ie.Len)]
+		off += int(ie.Len)
+
+		if i := bytes.IndexByte(name, 0); i >= 0 {
+			name = name[:i]
+		}
+
+		es, err := w.handle(ie.Wd, ie.Mask, string(name))
+		if err != nil {
+			sendWatchError(errs, err)
+		}
+
+		events = append(events, es...)
+	}
+
+	return events
+}
+
+func (w *inotifyWatch) handle(
+	wd int32,
+	mask uint32,
+	name string,
+) ([]WatchEvent, error) {
+	if mask&syscall.IN_Q_OVERFLOW != 0 {
+		return nil, ErrWatchOverflow
+	}
+
+	dir, ok := w.dirs[wd]
+	if !ok {
+		return nil, nil
+	}
+
+	if mask&syscall.IN_IGNORED != 0 {
+		delete(w.dirs, wd)
+		return nil, nil
+	}
+
+	// Events about the watched directory itself. Removals and renames are
+	// reported by the parent directory, except for the root.
+	if name == "" {
+		if mask&(syscall.IN_DELETE_SELF|syscall.IN_MOVE_SELF) == 0 {
+			return nil, nil
+		}
+
+		if dir == w.root {
+			return []WatchEvent{{Path: dir, Op: WatchRemove}}, nil
+		}
+
+		// Moved directories are watched again from their new location.
+		delete(w.dirs, wd)
+		syscall.InotifyRmWatch(w.fd, uint32(wd)) //nolint:errcheck
+
+		return nil, nil
+	}
+
+	path := filepath.Join(dir, name)
+
+	fi, err := os.Lstat(path)
+	if err != nil {
+		fi = watchInfo{name: name, dir: mask&syscall.IN_ISDIR != 0}
+	}
+
+	if !w.opts.include(relPath(w.root, path), fi) {
+		return nil, nil
+	}
+
+	var op WatchOp
+
+	switch {
+	case mask&(syscall.IN_CREATE|syscall.IN_MOVED_TO) != 0:
+		op = WatchCreate
+	case mask&syscall.IN_MODIFY != 0:
+		op = WatchWrite
+	case mask&syscall.IN_DELETE != 0:
+		op = WatchRemove
+	case mask&syscall.IN_MOVED_FROM != 0:
+		op = WatchRename
+	case mask&syscall.IN_ATTRIB != 0:
+		op = WatchChmod
+	}
+
+	events := []WatchEvent{{Path: path, Op: op}}
+
+	if op != WatchCreate || !w.opts.recursive || !fi.IsDir() {
+		return events, nil
+	}
+
+	// Files may be created before the new directory is watched.
+	created, err := w.addDir(path, true)
+	if errors.Is(err, fs.ErrNotExist) {
+		err = nil
+	} else if err != nil {
+		err = ErrWatch.Wrap(err)
+	}
+
+	return append(events, created...), err
+}
+
+// addDir watches dir and, if recursive, its subdirectories. If created is
+// true, events are returned for the entries found in the subdirectories.
+func (w *inotifyWatch) addDir(dir string, created bool) ([]WatchEvent, error) {
+	wd, err := syscall.InotifyAddWatch(w.fd, dir, inotifyMask)
+	if err != nil {
+		return nil, &os.PathError{Op: "inotify_add_watch", Path: dir, Err: err}
+	}
+
+	w.dirs[int32(wd)] = dir
+
+	if !w.opts.recursive {
+		return nil, nil
+	}
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, err //nolint:wrapcheck
+	}
+
+	var events []WatchEvent
+
+	for _, entry := range entries {
+		path := filepath.Join(dir, entry.Name())
+
+		fi, err := entry.Info()
+		if err != nil {
+			continue
+		}
+
+		if !w.opts.include(relPath(w.root, path), fi) {
+			continue
+		}
+
+		if created {
+			events = append(events, WatchEvent{Path: path, Op: WatchCreate})
+		}
+
+		if !entry.IsDir() {
+			continue
+		}
+
+		es, err := w.addDir(path, created)
+		if err != nil && !errors.Is(err, fs.ErrNotExist) {
+			return events, err
+		}
+
+		events = append(events, es...)
+	}
+
+	return events, nil
+}
+
+// watchInfo describes files that don't exist anymore.
+type watchInfo struct {
+	name string
+	dir  bool
+}
+
+func (fi watchInfo) Name() string       { return fi.name }
+func (fi watchInfo) Size() int64        { return 0 }
+func (fi watchInfo) ModTime() time.Time { return time.Time{} }
+func (fi watchInfo) IsDir() bool        { return fi.dir }
+func (fi watchInfo) Sys() any           { return nil }
+
+func (fi watchInfo) Mode() fs.FileMode {
+	if fi.dir {
+		return fs.ModeDir
+	}
+
+	return 0
+}
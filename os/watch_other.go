@@ -0,0 +1,10 @@
+// Copyright 2026 Miguel Angel Rivera Notararigo. All rights reserved.
+// This source code was released under the MIT license.
+
+//go:build !linux
+
+package os
+
+func newNativeWatch(root string, o watchOptions) (watchSource, error) {
+	return nil, ErrNotSupported
+}
@@ -0,0 +1,267 @@
+// Copyright 2026 Miguel Angel Rivera Notararigo. All rights reserved.
+// This source code was released under the MIT license.
+
+package os_test
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	ntos "go.ntrrg.dev/ntgo/os"
+)
+
+func TestWatch(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name string
+		opts []ntos.WatchOption
+	}{
+		{name: "native"},
+		{name: "polling", opts: []ntos.WatchOption{
+			ntos.WithPolling(10 * time.Millisecond),
+		}},
+	}
+
+	for _, c := range cases {
+		c := c
+
+		t.Run(c.name, func(t *testing.T) {
+			t.Parallel()
+
+			dir, err := os.MkdirTemp("", "ntgo-os-watch")
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			defer os.RemoveAll(dir)
+
+			writeTree(t, dir, map[string]string{
+				"file.txt":     "hello",
+				"old.txt":      "old",
+				"sub/file.txt": "nested",
+			})
+
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			opts := append([]ntos.WatchOption{
+				ntos.WithRecursive(),
+				ntos.WithDebounce(50 * time.Millisecond),
+				ntos.WithWatchFilter(ntos.Exclude("*.tmp")),
+			}, c.opts...)
+
+			w, err := ntos.Watch(ctx, dir, opts...)
+			if err != nil {
+				t.Fatalf("Watch failed: %v", err)
+			}
+
+			writeTree(t, dir, map[string]string{
+				"file.txt":         "hello, world!",
+				"ignored.tmp":      "ignored",
+				"sub/file.txt":     "changed",
+				"new/deep/new.txt": "new",
+			})
+
+			if err := os.Remove(filepath.Join(dir, "old.txt")); err != nil {
+				t.Fatal(err)
+			}
+
+			want := map[string]ntos.WatchOp{
+				"file.txt":         ntos.WatchWrite,
+				"sub/file.txt":     ntos.WatchWrite,
+				"new":              ntos.WatchCreate,
+				"new/deep/new.txt": ntos.WatchCreate,
+				"old.txt":          ntos.WatchRemove,
+			}
+
+			for _, e := range waitEvents(t, w, dir, want) {
+				if filepath.Ext(e.Path) == ".tmp" {
+					t.Errorf("excluded file was reported: %v", e)
+				}
+			}
+
+			cancel()
+
+			for range w.Events { //nolint:revive
+			}
+		})
+	}
+}
+
+func TestWatch_debounce(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name     string
+		maxWait  time.Duration
+		interval time.Duration
+		min, max int
+	}{
+		// Changes are delivered even if they never stop.
+		{
+			name: "Steady changes", maxWait: 200 * time.Millisecond,
+			interval: 10 * time.Millisecond, min: 2, max: 10,
+		},
+
+		// Changes are not delivered while they keep happening within the
+		// debounce interval.
+		{name: "Quiet period", interval: 20 * time.Millisecond},
+	}
+
+	for _, c := range cases {
+		c := c
+
+		t.Run(c.name, func(t *testing.T) {
+			t.Parallel()
+
+			dir, err := os.MkdirTemp("", "ntgo-os-watch_debounce")
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			defer os.RemoveAll(dir)
+
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			opts := []ntos.WatchOption{ntos.WithDebounce(100 * time.Millisecond)}
+			if c.maxWait > 0 {
+				opts = append(opts, ntos.WithMaxWait(c.maxWait))
+			}
+
+			w, err := ntos.Watch(ctx, dir, opts...)
+			if err != nil {
+				t.Fatalf("Watch failed: %v", err)
+			}
+
+			done := make(chan error)
+
+			go func() {
+				done <- appendFile(
+					filepath.Join(dir, "file.log"), c.interval, 40,
+				)
+			}()
+
+			var n int
+
+		loop:
+			for {
+				select {
+				case <-w.Events:
+					n++
+				case err := <-w.Errors:
+					t.Errorf("watcher error: %v", err)
+				case err := <-done:
+					if err != nil {
+						t.Fatal(err)
+					}
+
+					break loop
+				}
+			}
+
+			if n < c.min || n > c.max {
+				t.Errorf(
+					"invalid number of events before changes stopped. got: %d, "+
+						"want: [%d, %d]", n, c.min, c.max,
+				)
+			}
+
+			if n == 0 {
+				waitEvents(t, w, dir, map[string]ntos.WatchOp{
+					"file.log": ntos.WatchWrite,
+				})
+			}
+
+			cancel()
+
+			for range w.Events { //nolint:revive
+			}
+		})
+	}
+}
+
+func TestWatch_notDir(t *testing.T) {
+	t.Parallel()
+
+	dir, err := os.MkdirTemp("", "ntgo-os-watch_notdir")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	defer os.RemoveAll(dir)
+
+	writeTree(t, dir, map[string]string{"file.txt": "hello"})
+
+	_, err = ntos.Watch(context.Background(), filepath.Join(dir, "file.txt"))
+	if !errors.Is(err, ntos.ErrWatchNotDir) {
+		t.Errorf("invalid error. got: %v, want: %v", err, ntos.ErrWatchNotDir)
+	}
+}
+
+// waitEvents reads events from w until all the operations in want (indexed by
+// slash-separated paths relative to root) are reported.
+func waitEvents(
+	t *testing.T,
+	w *ntos.Watcher,
+	root string,
+	want map[string]ntos.WatchOp,
+) []ntos.WatchEvent {
+	t.Helper()
+
+	var got []ntos.WatchEvent
+
+	timeout := time.After(5 * time.Second)
+
+	for len(want) > 0 {
+		select {
+		case e := <-w.Events:
+			got = append(got, e)
+
+			rel, err := filepath.Rel(root, e.Path)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			rel = filepath.ToSlash(rel)
+			if op, ok := want[rel]; ok && e.Op.Has(op) {
+				delete(want, rel)
+			}
+		case err := <-w.Errors:
+			t.Errorf("watcher error: %v", err)
+		case <-timeout:
+			t.Fatalf("missing events: %v, got: %v", want, got)
+		}
+	}
+
+	return got
+}
+
+// appendFile appends a line to the file at path n times, waiting interval
+// between writes.
+func appendFile(path string, interval time.Duration, n int) error {
+	for i := 0; i < n; i++ {
+		f, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o644)
+		if err != nil {
+			return err //nolint:wrapcheck
+		}
+
+		_, err = f.WriteString("line\n")
+		if cerr := f.Close(); err == nil {
+			err = cerr
+		}
+
+		if err != nil {
+			return err //nolint:wrapcheck
+		}
+
+		time.Sleep(interval)
+	}
+
+	return nil
+}
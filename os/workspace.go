@@ -0,0 +1,321 @@
+// Copyright 2026 Miguel Angel Rivera Notararigo. All rights reserved.
+// This source code was released under the MIT license.
+
+package os
+
+import (
+	"context"
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	nterrors "go.ntrrg.dev/ntgo/errors"
+)
+
+// Workspace is a temporary directory for scratch files. The directory and the
+// paths tracked by the workspace are removed when it is closed.
+type Workspace struct {
+	dir  string
+	keep bool
+	done chan struct{}
+
+	mu     sync.Mutex
+	paths  []string
+	failed bool
+	closed bool
+}
+
+// NewWorkspace creates a workspace in the temporary directory (see
+// WithWorkspaceDir), which is closed when ctx is done.
+func NewWorkspace(
+	ctx context.Context,
+	opts ...WorkspaceOption,
+) (*Workspace, error) {
+	o := newWorkspaceOptions(opts)
+
+	dir, err := os.MkdirTemp(o.dir, o.pattern)
+	if err != nil {
+		return nil, ErrWorkspace.Wrap(err)
+	}
+
+	w := &Workspace{dir: dir, keep: o.keep, done: make(chan struct{})}
+
+	for _, seed := range o.seeds {
+		if err := seed(dir); err != nil {
+			os.RemoveAll(dir)
+			return nil, err
+		}
+	}
+
+	go func() {
+		select {
+		case <-ctx.Done():
+			w.Close()
+		case <-w.done:
+		}
+	}()
+
+	return w, nil
+}
+
+// Close removes the workspace directory and its tracked paths. If the
+// workspace failed and WithKeepOnFailure was used, nothing is removed. Calling
+// Close multiple times has no effect.
+func (w *Workspace) Close() error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if w.closed {
+		return nil
+	}
+
+	w.closed = true
+	close(w.done)
+
+	if w.failed && w.keep {
+		return nil
+	}
+
+	var errs []error
+
+	// Tracked paths may be outside the workspace directory, they are removed
+	// in reverse order, so nested paths are removed first.
+	for i := len(w.paths) - 1; i >= 0; i-- {
+		err := os.RemoveAll(w.paths[i])
+		if err != nil && !errors.Is(err, fs.ErrNotExist) {
+			errs = append(errs, err)
+		}
+	}
+
+	if err := os.RemoveAll(w.dir); err != nil {
+		errs = append(errs, err)
+	}
+
+	if len(errs) > 0 {
+		return ErrWorkspace.Wrap(nterrors.Group(errs...))
+	}
+
+	return nil
+}
+
+// Create creates or truncates the named file in the workspace and tracks it.
+// Parent directories are created as needed. Names outside the workspace
+// directory are reported as ErrWorkspace errors, as in Mkdir and WriteFile.
+func (w *Workspace) Create(name string) (*os.File, error) {
+	path, err := w.path(name)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := w.mkdirAll(filepath.Dir(path)); err != nil {
+		return nil, err
+	}
+
+	f, err := os.Create(path)
+	if err != nil {
+		return nil, ErrWorkspace.Wrap(err)
+	}
+
+	w.Track(path)
+
+	return f, nil
+}
+
+// Dir returns the workspace directory.
+func (w *Workspace) Dir() string {
+	return w.dir
+}
+
+// Fail marks the workspace as failed, see WithKeepOnFailure.
+func (w *Workspace) Fail() {
+	w.mu.Lock()
+	w.failed = true
+	w.mu.Unlock()
+}
+
+// Failed reports if the workspace was marked as failed.
+func (w *Workspace) Failed() bool {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	return w.failed
+}
+
+// Mkdir creates the named directory in the workspace and tracks it. Parent
+// directories are created as needed.
+func (w *Workspace) Mkdir(name string) (string, error) {
+	path, err := w.path(name)
+	if err != nil {
+		return "", err
+	}
+
+	if err := w.mkdirAll(path); err != nil {
+		return "", err
+	}
+
+	return path, nil
+}
+
+// Path joins elem to the workspace directory.
+func (w *Workspace) Path(elem ...string) string {
+	return filepath.Join(append([]string{w.dir}, elem...)...)
+}
+
+// Paths returns the tracked paths in creation order.
+func (w *Workspace) Paths() []string {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	return append([]string(nil), w.paths...)
+}
+
+// Track registers paths to be removed when the workspace is closed. They may
+// be outside the workspace directory.
+func (w *Workspace) Track(paths ...string) {
+	w.mu.Lock()
+	w.paths = append(w.paths, paths...)
+	w.mu.Unlock()
+}
+
+// WriteFile writes data to the named file in the workspace and tracks it.
+// Parent directories are created as needed.
+func (w *Workspace) WriteFile(
+	name string,
+	data []byte,
+	perm fs.FileMode,
+) error {
+	path, err := w.path(name)
+	if err != nil {
+		return err
+	}
+
+	if err := w.mkdirAll(filepath.Dir(path)); err != nil {
+		return err
+	}
+
+	if err := os.WriteFile(path, data, perm); err != nil {
+		return ErrWorkspace.Wrap(err)
+	}
+
+	w.Track(path)
+
+	return nil
+}
+
+// path joins name to the workspace directory, names outside of it are
+// rejected.
+func (w *Workspace) path(name string) (string, error) {
+	path := w.Path(name)
+
+	rel, err := filepath.Rel(w.dir, path)
+	if err != nil {
+		return "", ErrWorkspace.Wrap(err)
+	}
+
+	if rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return "", ErrWorkspace.Wrap(
+			errors.New("'" + name + "' is outside the workspace"),
+		)
+	}
+
+	return path, nil
+}
+
+// mkdirAll creates path and its missing parents, tracking every created
+// directory.
+func (w *Workspace) mkdirAll(path string) error {
+	if path == w.dir {
+		return nil
+	}
+
+	if fi, err := os.Stat(path); err == nil && fi.IsDir() {
+		return nil
+	}
+
+	if err := w.mkdirAll(filepath.Dir(path)); err != nil {
+		return err
+	}
+
+	if err := os.Mkdir(path, 0o755); err != nil {
+		// Directories may be created concurrently.
+		if fi, serr := os.Stat(path); serr == nil && fi.IsDir() {
+			return nil
+		}
+
+		return ErrWorkspace.Wrap(err)
+	}
+
+	w.Track(path)
+
+	return nil
+}
+
+// WorkspaceOption customizes a workspace.
+type WorkspaceOption func(*workspaceOptions)
+
+// WithKeepOnFailure keeps the workspace when it is closed after being marked
+// as failed (see Workspace.Fail), so its content can be inspected.
+func WithKeepOnFailure() WorkspaceOption {
+	return func(o *workspaceOptions) {
+		o.keep = true
+	}
+}
+
+// WithSeed copies src into the workspace. If src is a directory, its content
+// is copied, otherwise src is copied with its base name.
+func WithSeed(src string, opts ...CopyOption) WorkspaceOption {
+	return func(o *workspaceOptions) {
+		o.seeds = append(o.seeds, func(dir string) error {
+			fi, err := os.Stat(src)
+			if err != nil {
+				return NewCopyError(ErrCopyStat, src, dir, err)
+			}
+
+			if fi.IsDir() {
+				return CopyDir(dir, src, fi.Mode(), opts...)
+			}
+
+			dst := filepath.Join(dir, filepath.Base(src))
+
+			return CopyFile(dst, src, fi.Mode(), opts...)
+		})
+	}
+}
+
+// WithSeedFS copies fsys content into the workspace, see CopyFS.
+func WithSeedFS(fsys fs.FS, opts ...CopyOption) WorkspaceOption {
+	return func(o *workspaceOptions) {
+		o.seeds = append(o.seeds, func(dir string) error {
+			return CopyFS(dir, fsys, opts...)
+		})
+	}
+}
+
+// WithWorkspaceDir sets the directory where the workspace is created and the
+// pattern for its name (see os.MkdirTemp). Default is the temporary directory
+// and "ntgo-workspace-*".
+func WithWorkspaceDir(dir, pattern string) WorkspaceOption {
+	return func(o *workspaceOptions) {
+		o.dir, o.pattern = dir, pattern
+	}
+}
+
+type workspaceOptions struct {
+	dir     string
+	keep    bool
+	pattern string
+	seeds   []func(dir string) error
+}
+
+func newWorkspaceOptions(opts []WorkspaceOption) workspaceOptions {
+	o := workspaceOptions{pattern: "ntgo-workspace-*"}
+
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	return o
+}
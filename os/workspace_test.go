@@ -0,0 +1,188 @@
+// Copyright 2026 Miguel Angel Rivera Notararigo. All rights reserved.
+// This source code was released under the MIT license.
+
+package os_test
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"testing/fstest"
+	"time"
+
+	ntos "go.ntrrg.dev/ntgo/os"
+)
+
+func TestWorkspace(t *testing.T) {
+	t.Parallel()
+
+	dir, err := os.MkdirTemp("", "ntgo-os-workspace")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	defer os.RemoveAll(dir)
+
+	src := filepath.Join(dir, "source")
+	writeTree(t, src, map[string]string{"sub/file.txt": "from directory"})
+
+	fsys := fstest.MapFS{"fs.txt": {Data: []byte("from fs")}}
+
+	w, err := ntos.NewWorkspace(
+		context.Background(),
+		ntos.WithWorkspaceDir(dir, "workspace-*"),
+		ntos.WithSeed(src),
+		ntos.WithSeedFS(fsys),
+	)
+
+	if err != nil {
+		t.Fatalf("NewWorkspace failed: %v", err)
+	}
+
+	if filepath.Dir(w.Dir()) != dir {
+		t.Errorf("invalid workspace location: %s", w.Dir())
+	}
+
+	for name, want := range map[string]string{
+		"sub/file.txt": "from directory",
+		"fs.txt":       "from fs",
+	} {
+		data, err := os.ReadFile(w.Path(name))
+		if err != nil {
+			t.Errorf("seed file %s was not copied: %v", name, err)
+		} else if string(data) != want {
+			t.Errorf("invalid content. got: %q, want: %q", data, want)
+		}
+	}
+
+	if err := w.WriteFile("a/b/file.txt", []byte("new"), 0o600); err != nil {
+		t.Fatalf("WriteFile failed: %v", err)
+	}
+
+	outside := filepath.Join(dir, "outside")
+	writeTree(t, outside, map[string]string{"file.txt": "tracked"})
+	w.Track(outside)
+
+	want := []string{w.Path("a"), w.Path("a/b"), w.Path("a/b/file.txt"), outside}
+	got := w.Paths()
+	if strings.Join(got, ", ") != strings.Join(want, ", ") {
+		t.Errorf("invalid tracked paths. got: %q, want: %q", got, want)
+	}
+
+	if err := w.Close(); err != nil {
+		t.Fatalf("Close failed: %v", err)
+	}
+
+	for _, path := range []string{w.Dir(), outside} {
+		if _, err := os.Stat(path); err == nil {
+			t.Errorf("%s was not removed", path)
+		}
+	}
+
+	if err := w.Close(); err != nil {
+		t.Errorf("second Close failed: %v", err)
+	}
+}
+
+func TestWorkspace_outside(t *testing.T) {
+	t.Parallel()
+
+	dir, err := os.MkdirTemp("", "ntgo-os-workspace_outside")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	defer os.RemoveAll(dir)
+
+	writeTree(t, dir, map[string]string{"keep/file.txt": "outside"})
+
+	w, err := ntos.NewWorkspace(
+		context.Background(),
+		ntos.WithWorkspaceDir(dir, "workspace-*"),
+	)
+
+	if err != nil {
+		t.Fatalf("NewWorkspace failed: %v", err)
+	}
+
+	for _, name := range []string{"..", "../keep/file.txt", "a/../../keep"} {
+		if f, err := w.Create(name); !errors.Is(err, ntos.ErrWorkspace) {
+			t.Errorf("[%s] invalid Create error: %v", name, err)
+
+			if f != nil {
+				f.Close()
+			}
+		}
+
+		if _, err := w.Mkdir(name); !errors.Is(err, ntos.ErrWorkspace) {
+			t.Errorf("[%s] invalid Mkdir error: %v", name, err)
+		}
+
+		err := w.WriteFile(name, []byte("inside"), 0o600)
+		if !errors.Is(err, ntos.ErrWorkspace) {
+			t.Errorf("[%s] invalid WriteFile error: %v", name, err)
+		}
+	}
+
+	if err := w.Close(); err != nil {
+		t.Fatalf("Close failed: %v", err)
+	}
+
+	data, err := os.ReadFile(filepath.Join(dir, "keep", "file.txt"))
+	if err != nil || string(data) != "outside" {
+		t.Errorf("file outside the workspace was modified: %q (%v)", data, err)
+	}
+}
+
+func TestWorkspace_keepOnFailure(t *testing.T) {
+	t.Parallel()
+
+	w, err := ntos.NewWorkspace(
+		context.Background(),
+		ntos.WithKeepOnFailure(),
+	)
+
+	if err != nil {
+		t.Fatalf("NewWorkspace failed: %v", err)
+	}
+
+	defer os.RemoveAll(w.Dir())
+
+	w.Fail()
+
+	if err := w.Close(); err != nil {
+		t.Fatalf("Close failed: %v", err)
+	}
+
+	if _, err := os.Stat(w.Dir()); err != nil {
+		t.Errorf("failed workspace was removed: %v", err)
+	}
+}
+
+func TestWorkspace_context(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithCancel(context.Background())
+
+	w, err := ntos.NewWorkspace(ctx)
+	if err != nil {
+		t.Fatalf("NewWorkspace failed: %v", err)
+	}
+
+	defer w.Close()
+
+	cancel()
+
+	for i := 0; i < 100; i++ {
+		if _, err := os.Stat(w.Dir()); err != nil {
+			return
+		}
+
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	t.Error("workspace was not removed after cancelling its context")
+}